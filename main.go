@@ -42,16 +42,9 @@ var rootCmd = &cobra.Command{
 	Short: "A customizable code generator to quickly set up APIs in Go.",
 	Long: `Makego is a customizable code generator that sets up the basics of an API framework in Go
 to let you quickly launch an api.`,
-	PreRunE: func(cmd *cobra.Command, args []string) error {
-		// Return values from viper back to cobra if needed
-		cmd.Flags().VisitAll(func(f *pflag.Flag) {
-			if !f.Changed && viper.IsSet(f.Name) {
-				f.Value.Set(viper.GetString(f.Name))
-			}
-		})
-
-		return viper.UnmarshalKey("templates", &project.Templates)
-	},
+	// Expect either 0 (allowed if `go.mod` already exists) or 1, for package name
+	Args:    cobra.MaximumNArgs(1),
+	PreRunE: loadConfig,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) > 0 {
 			project.PkgName = args[0]
@@ -64,9 +57,6 @@ to let you quickly launch an api.`,
 func init() {
 	cobra.OnInitialize(initConfig)
 
-	// Expect either 0 (allowed if `go.mod` already exists) or 1, for package name
-	cobra.MaximumNArgs(1)
-
 	// Set all flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/makego.yaml)")
 
@@ -76,8 +66,15 @@ func init() {
 	rootCmd.Flags().StringVar(&project.Copyright, "copyright", "", "copyright holder (and contact if desired)")
 	rootCmd.Flags().StringVar(&project.Database.Name, "database", "postgres", "database type to use (mysql, mariadb, postgres, etc)")
 	rootCmd.Flags().StringVar(&project.ORM.Name, "orm", "gorm", "ORM to use for models (defaults to gorm)")
-	rootCmd.Flags().StringVar(&project.Router.Name, "router", "gin", "router to use (echo, gin, http, mux)")
+	rootCmd.Flags().StringVar(&project.Router.Name, "router", "gin", "router to use (chi, echo, fiber, gin, http, mux)")
 	rootCmd.Flags().StringVar(&project.EnvPrefix, "envprefix", "", "how to expect env variables to be prefixed")
+	rootCmd.Flags().StringVar(&project.APIVersion, "api-version", "v1", "version the API is served as, under /api/<version>")
+	rootCmd.Flags().StringVar(&project.FromOpenAPI, "from-openapi", "", "OpenAPI 3 document (YAML or JSON) to generate the API from")
+	rootCmd.Flags().StringVar((*string)(&project.Transport), "transport", "http", "how the app is served (http, grpc, both)")
+	rootCmd.Flags().StringSliceVar(&project.Features, "feature", nil, "optional feature to enable (auth, authz, graphql, grpc, metrics, oidc, openapi, ratelimit, realtime), can be repeated")
+	rootCmd.Flags().StringSliceVar(&project.ComponentDirs, "component-dir", nil, "folder of router, ORM and database definitions, can be repeated")
+	rootCmd.Flags().StringSliceVar(&project.Packs, "pack", nil, "folder of extra files laid out like the built-in templates, can be repeated")
+	rootCmd.Flags().StringArrayVar(&project.Set, "set", nil, "set a template variable (key=value), can be repeated")
 
 	rootCmd.Flags().BoolVarP(&project.Docker, "docker", "d", false, "whether to use docker")
 	rootCmd.Flags().BoolVarP(&project.Header, "header", "a", false, "whether to show copyright headers on most files")
@@ -87,6 +84,43 @@ func init() {
 	cobra.CheckErr(err)
 }
 
+// loadConfig returns values from the config file to the flags and project.
+func loadConfig(cmd *cobra.Command, args []string) error {
+	// Return values from viper back to cobra if needed
+	cmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if !f.Changed && viper.IsSet(f.Name) {
+			if s, ok := f.Value.(pflag.SliceValue); ok {
+				s.Replace(viper.GetStringSlice(f.Name))
+				return
+			}
+			f.Value.Set(viper.GetString(f.Name))
+		}
+	})
+
+	// The config lists features under features, rather than after the flag
+	if !cmd.Flags().Changed("feature") && viper.IsSet("features") {
+		project.Features = viper.GetStringSlice("features")
+	}
+
+	if err := viper.UnmarshalKey("vars", &project.Vars); err != nil {
+		return err
+	}
+
+	if err := viper.UnmarshalKey("blocks", &project.Blocks); err != nil {
+		return err
+	}
+
+	if err := viper.UnmarshalKey("templates", &project.Templates); err != nil {
+		return err
+	}
+
+	if err := viper.UnmarshalKey("components", &project.Components); err != nil {
+		return err
+	}
+
+	return project.LoadComponents()
+}
+
 // initConfig reads in config file and ENV variables if set.
 func initConfig() {
 	if cfgFile != "" {
@@ -0,0 +1,397 @@
+package src
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/jason-jackson/makego/templates"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	kindDatabase = "database"
+	kindFeature  = "feature"
+	kindORM      = "orm"
+	kindRouter   = "router"
+	kindSpec     = "spec"
+)
+
+// Component is a pluggable part of a generated project, like its router.
+type Component interface {
+	Kind() string
+	Info() *ComponentInfo
+
+	goPackages() []string
+}
+
+// ComponentInfo is what every component declares, whatever its kind.
+// Other components are referred to as kind/name, like database/postgres.
+type ComponentInfo struct {
+	Name    string
+	Matches []string
+
+	// Go packages to get, besides the component's own package, rendered
+	// as templates, so they can be left out for some of the other components
+	Packages []string
+	// Named templates, blocks with the same name from several components are joined
+	Blocks map[string]string
+	// Environment variables the generated app needs, with example values
+	Env map[string]string
+	// Commands run in the project once its files are made, before go mod tidy,
+	// like code generators, with the arguments separated by spaces
+	Commands []string
+
+	// Components that must be selected as well
+	Requires []string
+	// The only components of each kind listed that this works with
+	Compatible []string
+	// Components that can't be selected at the same time
+	Conflicts []string
+
+	// Files the component adds to the project, from the folder next to
+	// its definition, named after it and laid out like templates/files
+	files fs.FS
+}
+
+func (c *ComponentInfo) defaultName(name string) string {
+	if c.Name == "" {
+		c.Name = name
+	}
+
+	return c.Name
+}
+
+func (c *ComponentInfo) setFiles(fSys fs.FS) {
+	c.files = fSys
+}
+
+// EnvVar is an environment variable the generated app needs.
+type EnvVar struct {
+	Name  string
+	Value string
+}
+
+// Components are router, ORM, database, feature and spec definitions from the config, keyed by name.
+type Components struct {
+	Routers   map[string]Router
+	ORMs      map[string]ORM
+	Databases map[string]Database
+	Features  map[string]Feature
+	Specs     map[string]Spec
+}
+
+// registry holds every known component by kind and name.
+type registry map[string]map[string]Component
+
+// kinds makes an empty component of each kind, to be
+// loaded from the folder named after the kind's plural.
+var kinds = map[string]func() Component{
+	kindDatabase: func() Component { return &Database{} },
+	kindFeature:  func() Component { return &Feature{} },
+	kindORM:      func() Component { return &ORM{} },
+	kindRouter:   func() Component { return &Router{} },
+	kindSpec:     func() Component { return &Spec{} },
+}
+
+var components = registry{}
+
+func init() {
+	fSys, err := fs.Sub(templates.FS, "components")
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	if err := components.load(fSys); err != nil {
+		log.Fatalln(err)
+	}
+}
+
+// LoadComponents adds the components from the component folders and the
+// components config, replacing any existing components with the same name.
+func (p *Project) LoadComponents() error {
+	for _, dir := range p.ComponentDirs {
+		if err := components.load(os.DirFS(dir)); err != nil {
+			return fmt.Errorf("unable to load components from %s: %w", dir, err)
+		}
+	}
+
+	for k, r := range p.Components.Routers {
+		components.add(k, &r)
+	}
+
+	for k, o := range p.Components.ORMs {
+		components.add(k, &o)
+	}
+
+	for k, db := range p.Components.Databases {
+		components.add(k, &db)
+	}
+
+	for k, f := range p.Components.Features {
+		components.add(k, &f)
+	}
+
+	for k, s := range p.Components.Specs {
+		components.add(k, &s)
+	}
+
+	return nil
+}
+
+// resolve checks that the selected components can be used together, selecting
+// any they require, and merges the packages, blocks and environment they contribute.
+func (p *Project) resolve(selected ...Component) error {
+	// selected grows as requirements are added, so their requirements are checked too
+	for i := 0; i < len(selected); i++ {
+		c := selected[i]
+		for _, ref := range c.Info().Requires {
+			kind, name := splitRef(ref)
+			if slices.ContainsFunc(selected, refersTo(kind, name)) {
+				continue
+			}
+
+			// Any number of features can be selected, but only one of anything else
+			if other := slices.IndexFunc(selected, ofKind(kind)); other != -1 && kind != kindFeature {
+				return incompatibleError(fmt.Sprintf("%s requires %s, not %s", describe(c), ref, describe(selected[other])))
+			}
+
+			r, err := components.find(kind, name)
+			if err != nil {
+				return fmt.Errorf("%s requires %s: %w", describe(c), ref, err)
+			}
+			selected = append(selected, r)
+		}
+	}
+
+	for _, c := range selected {
+		if err := checkPeers(c, selected); err != nil {
+			return err
+		}
+	}
+
+	blocks := map[string]string{}
+	env := map[string]string{}
+	p.selected = selected
+	for _, c := range selected {
+		for _, pkg := range c.goPackages() {
+			// Packages are templates too, so they can depend on the other components
+			b, err := p.render(describe(c)+" package", pkg)
+			if err != nil {
+				return fmt.Errorf("unable to parse the packages of %s: %w", describe(c), err)
+			}
+			pkg = string(b)
+
+			// The standard library comes with go, so there's nothing to get
+			if pkg != "" && !isStdLib(pkg) && !slices.Contains(p.packages, pkg) {
+				p.packages = append(p.packages, pkg)
+			}
+		}
+
+		for name, content := range c.Info().Blocks {
+			blocks[p.templateName(name)] += content
+		}
+
+		for name, value := range c.Info().Env {
+			if prev, ok := env[name]; ok && prev != value {
+				return fmt.Errorf("%s sets %s to %q, but it is already %q", describe(c), name, value, prev)
+			}
+			env[name] = value
+		}
+	}
+
+	for _, name := range sortedKeys(blocks) {
+		if err := p.addNamedTemplate(name, blocks[name]); err != nil {
+			return fmt.Errorf("unable to parse block %s: %w", name, err)
+		}
+	}
+
+	p.env = nil
+	for _, name := range sortedKeys(env) {
+		p.env = append(p.env, EnvVar{Name: name, Value: env[name]})
+	}
+
+	return nil
+}
+
+// incompatibleError is returned when the selected components can't be used together
+type incompatibleError string
+
+func (e incompatibleError) Error() string {
+	return string(e)
+}
+
+// checkPeers makes sure c is compatible with, and doesn't conflict with, the other selected components.
+func checkPeers(c Component, selected []Component) error {
+	compatible := map[string][]string{}
+	for _, ref := range c.Info().Compatible {
+		kind, name := splitRef(ref)
+		compatible[kind] = append(compatible[kind], name)
+	}
+
+	for _, other := range selected {
+		if names, ok := compatible[other.Kind()]; ok {
+			if !slices.ContainsFunc(names, func(name string) bool { return refersTo(other.Kind(), name)(other) }) {
+				return incompatibleError(fmt.Sprintf("%s is not compatible with %s", describe(c), describe(other)))
+			}
+		}
+
+		for _, ref := range c.Info().Conflicts {
+			if refersTo(splitRef(ref))(other) {
+				return incompatibleError(fmt.Sprintf("%s conflicts with %s", describe(c), describe(other)))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r registry) add(name string, c Component) {
+	name = strings.ToLower(c.Info().defaultName(strings.ToLower(name)))
+	if r[c.Kind()] == nil {
+		r[c.Kind()] = map[string]Component{}
+	}
+
+	r[c.Kind()][name] = c
+}
+
+func (r registry) find(kind, name string) (Component, error) {
+	name = strings.ToLower(name)
+
+	if c, ok := r[kind][name]; ok {
+		return c, nil
+	}
+
+	for _, k := range sortedKeys(r[kind]) {
+		if refersTo(kind, name)(r[kind][k]) {
+			return r[kind][k], nil
+		}
+	}
+
+	return nil, fmt.Errorf("no %s matching: %s", kindLabel(kind), name)
+}
+
+func (r registry) names(kind string) []string {
+	return sortedKeys(r[kind])
+}
+
+// load reads a folder laid out like templates/components,
+// with one YAML file per component in a folder for each kind.
+func (r registry) load(fSys fs.FS) error {
+	for _, kind := range sortedKeys(kinds) {
+		if err := r.loadFolder(fSys, kind); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadFolder reads each YAML file in the folder for kind, naming the
+// component after the file, without its extension, unless it has a name.
+// A folder named like the file, next to it, holds the component's files.
+func (r registry) loadFolder(fSys fs.FS, kind string) error {
+	folder := kind + "s"
+	entries, err := fs.ReadDir(fSys, folder)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		ext := path.Ext(e.Name())
+		if e.IsDir() || (ext != ".yml" && ext != ".yaml") {
+			continue
+		}
+
+		file := path.Join(folder, e.Name())
+		contents, err := fs.ReadFile(fSys, file)
+		if err != nil {
+			return err
+		}
+
+		c := kinds[kind]()
+		dec := yaml.NewDecoder(bytes.NewReader(contents))
+		dec.KnownFields(true)
+		if err := dec.Decode(c); err != nil {
+			return fmt.Errorf("unable to parse %s: %w", file, err)
+		}
+
+		name := strings.TrimSuffix(e.Name(), ext)
+		if info, err := fs.Stat(fSys, path.Join(folder, name)); err == nil && info.IsDir() {
+			files, err := fs.Sub(fSys, path.Join(folder, name))
+			if err != nil {
+				return err
+			}
+			c.Info().setFiles(files)
+		}
+
+		r.add(name, c)
+	}
+
+	return nil
+}
+
+// findComponent returns a copy of the registered component of type T matching name.
+func findComponent[T any, P interface {
+	*T
+	Component
+}](name string) (T, error) {
+	c, err := components.find(P(new(T)).Kind(), name)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return *c.(P), nil
+}
+
+// describe names a component for messages, like `router gin`.
+func describe(c Component) string {
+	return kindLabel(c.Kind()) + " " + c.Info().Name
+}
+
+func kindLabel(kind string) string {
+	if kind == kindORM {
+		return "ORM"
+	}
+
+	return kind
+}
+
+// ofKind matches components of kind.
+func ofKind(kind string) func(Component) bool {
+	return func(c Component) bool {
+		return strings.EqualFold(kind, c.Kind())
+	}
+}
+
+// refersTo matches the component of kind named, or matched by, name.
+func refersTo(kind, name string) func(Component) bool {
+	return func(c Component) bool {
+		if !ofKind(kind)(c) {
+			return false
+		}
+
+		if strings.EqualFold(name, c.Info().Name) {
+			return true
+		}
+
+		return slices.ContainsFunc(c.Info().Matches, func(m string) bool {
+			return strings.EqualFold(name, m)
+		})
+	}
+}
+
+// splitRef splits a kind/name reference to another component.
+func splitRef(ref string) (string, string) {
+	kind, name, _ := strings.Cut(ref, "/")
+	return strings.ToLower(kind), name
+}
@@ -0,0 +1,277 @@
+package src
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"text/template"
+)
+
+func Test_registry_loadFolder(t *testing.T) {
+	testCases := []struct {
+		name    string
+		files   fstest.MapFS
+		want    map[string]string
+		wantErr string
+	}{
+		{
+			name:  "no folder",
+			files: fstest.MapFS{},
+			want:  map[string]string{},
+		},
+		{
+			name: "named after file",
+			files: fstest.MapFS{
+				"routers/chi.yml":   {Data: []byte("package: github.com/go-chi/chi/v5\n")},
+				"routers/Echo.yaml": {Data: []byte("package: github.com/labstack/echo/v4\n")},
+			},
+			want: map[string]string{
+				"chi":  "github.com/go-chi/chi/v5",
+				"echo": "github.com/labstack/echo/v4",
+			},
+		},
+		{
+			name: "name in file",
+			files: fstest.MapFS{
+				"routers/wrapper.yml": {Data: []byte("name: inhouse\npackage: example.com/router\n")},
+			},
+			want: map[string]string{"inhouse": "example.com/router"},
+		},
+		{
+			name: "other files are ignored",
+			files: fstest.MapFS{
+				"routers/README.md":      {Data: []byte("# Routers")},
+				"routers/nested/gin.yml": {Data: []byte("package: github.com/gin-gonic/gin\n")},
+			},
+			want: map[string]string{},
+		},
+		{
+			name: "unknown field",
+			files: fstest.MapFS{
+				"routers/chi.yml": {Data: []byte("pakage: github.com/go-chi/chi/v5\n")},
+			},
+			wantErr: "unable to parse routers/chi.yml",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			r := registry{}
+			err := r.loadFolder(tC.files, kindRouter)
+			if tC.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tC.wantErr) {
+					t.Errorf("expected `%v` to contain `%s`", err, tC.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if len(tC.want) != len(r[kindRouter]) {
+				t.Errorf("expected %d routers, got: %v", len(tC.want), r[kindRouter])
+			}
+			for k, pkg := range tC.want {
+				got, ok := r[kindRouter][k].(*Router)
+				if !ok || got.Name != k || got.Package != pkg {
+					t.Errorf("expected `%s` with package `%s` got: `%+v`", k, pkg, r[kindRouter][k])
+				}
+			}
+		})
+	}
+}
+
+func Test_registry_loadFolder_files(t *testing.T) {
+	r := registry{}
+	err := r.loadFolder(fstest.MapFS{
+		"features/cache.yml": {Data: []byte("packages: [github.com/redis/go-redis/v9]\n")},
+		"features/cache/__application__/cache/cache.go.template": {Data: []byte("package cache\n")},
+		"features/tracing.yml": {Data: []byte("packages: [go.opentelemetry.io/otel]\n")},
+	}, kindFeature)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	cache := r[kindFeature]["cache"].(*Feature)
+	if cache.files == nil {
+		t.Fatal("expected cache to have files")
+	}
+	if _, err := cache.files.Open("__application__/cache/cache.go.template"); err != nil {
+		t.Errorf("expected cache files to be relative to its folder: %s", err)
+	}
+
+	if tracing := r[kindFeature]["tracing"].(*Feature); tracing.files != nil {
+		t.Error("expected tracing to have no files")
+	}
+}
+
+func Test_builtinComponents(t *testing.T) {
+	for kind := range kinds {
+		if len(components[kind]) == 0 {
+			t.Errorf("no built-in %s", kind)
+		}
+
+		for name, c := range components[kind] {
+			// Only routers and ORMs need packages of their own
+			if c.Info().Name != name || len(c.goPackages()) == 0 && (kind == kindRouter || kind == kindORM) {
+				t.Errorf("incomplete %s %s: %+v", kind, name, c)
+			}
+		}
+	}
+}
+
+func Test_resolve(t *testing.T) {
+	router := func(info ComponentInfo) *Router {
+		r := &Router{ComponentInfo: info, Package: "example.com/router"}
+		r.Name = "router"
+		return r
+	}
+	feature := func(name string, requires ...string) *Feature {
+		f := &Feature{}
+		f.Name = name
+		f.Requires = requires
+		return f
+	}
+	database := func(name string, env map[string]string) *Database {
+		db := &Database{}
+		db.Name = name
+		db.Matches = []string{name + "ql"}
+		db.Env = env
+		return db
+	}
+
+	testCases := []struct {
+		name         string
+		selected     []Component
+		wantPackages []string
+		wantEnv      []EnvVar
+		wantFeatures FeatureSet
+		wantRoutes   string
+		wantErr      string
+	}{
+		{
+			name:         "packages and env",
+			selected:     []Component{database("postgres", map[string]string{"DSN": "pg"}), router(ComponentInfo{Packages: []string{"example.com/router", "example.com/extra"}})},
+			wantPackages: []string{"example.com/router", "example.com/extra"},
+			wantEnv:      []EnvVar{{Name: "DSN", Value: "pg"}},
+		},
+		{
+			name:         "compatible",
+			selected:     []Component{database("postgres", nil), router(ComponentInfo{Compatible: []string{"database/mysql", "database/postgresql"}})},
+			wantPackages: []string{"example.com/router"},
+		},
+		{
+			name:     "not compatible",
+			selected: []Component{database("sqlite", nil), router(ComponentInfo{Compatible: []string{"database/mysql", "database/postgres"}})},
+			wantErr:  "router router is not compatible with database sqlite",
+		},
+		{
+			name:     "conflicts",
+			selected: []Component{database("sqlite", nil), router(ComponentInfo{Conflicts: []string{"database/sqlite"}})},
+			wantErr:  "router router conflicts with database sqlite",
+		},
+		{
+			name:         "requires selected",
+			selected:     []Component{database("postgres", nil), router(ComponentInfo{Requires: []string{"database/postgres"}})},
+			wantPackages: []string{"example.com/router"},
+		},
+		{
+			name:     "requires other",
+			selected: []Component{database("sqlite", nil), router(ComponentInfo{Requires: []string{"database/postgres"}})},
+			wantErr:  "router router requires database/postgres, not database sqlite",
+		},
+		{
+			name:         "requires unselected",
+			selected:     []Component{router(ComponentInfo{Requires: []string{"database/postgres"}})},
+			wantPackages: []string{"example.com/router"},
+			wantEnv:      []EnvVar{{Name: "DSN", Value: "registered"}},
+		},
+		{
+			name:     "requires unknown",
+			selected: []Component{router(ComponentInfo{Requires: []string{"database/oracle"}})},
+			wantErr:  "no database matching: oracle",
+		},
+		{
+			name:         "features",
+			selected:     []Component{router(ComponentInfo{}), feature("metrics"), feature("tracing")},
+			wantPackages: []string{"example.com/router"},
+			wantFeatures: FeatureSet{"metrics", "tracing"},
+		},
+		{
+			name: "packages for other components",
+			selected: []Component{
+				router(ComponentInfo{}),
+				feature("metrics"),
+				&Feature{ComponentInfo: ComponentInfo{Name: "tracing", Packages: []string{
+					`{{ if .Features.Has "metrics" }}example.com/tracing/metrics{{ end }}`,
+					`{{ if .Features.Has "logs" }}example.com/tracing/logs{{ end }}`,
+				}}},
+			},
+			wantPackages: []string{"example.com/router", "example.com/tracing/metrics"},
+		},
+		{
+			name:     "package template",
+			selected: []Component{router(ComponentInfo{}), &Feature{ComponentInfo: ComponentInfo{Name: "tracing", Packages: []string{"{{ .Missing"}}}},
+			wantErr:  "unable to parse the packages of feature tracing",
+		},
+		{
+			name:         "features require features",
+			selected:     []Component{router(ComponentInfo{}), feature("authz", "feature/auth"), feature("metrics")},
+			wantPackages: []string{"example.com/router"},
+			wantFeatures: FeatureSet{"authz", "metrics", "auth"},
+		},
+		{
+			name: "blocks joined without case",
+			selected: []Component{
+				router(ComponentInfo{}),
+				&Feature{ComponentInfo: ComponentInfo{Name: "metrics", Blocks: map[string]string{"Routes": "metrics;"}}},
+				&Feature{ComponentInfo: ComponentInfo{Name: "pprof", Blocks: map[string]string{"routes": "pprof;"}}},
+			},
+			wantPackages: []string{"example.com/router"},
+			wantRoutes:   "metrics;pprof;",
+		},
+		{
+			name:     "env conflict",
+			selected: []Component{database("postgres", map[string]string{"DSN": "pg"}), router(ComponentInfo{Env: map[string]string{"DSN": "other"}})},
+			wantErr:  "router router sets DSN",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			defer func(r registry) { components = r }(components)
+			components = registry{}
+			components.add("postgres", database("postgres", map[string]string{"DSN": "registered"}))
+			components.add("auth", feature("auth"))
+
+			p := Project{templates: template.Must(template.New("").Parse(`{{ define "Routes" }}{{ end }}`))}
+			err := p.resolve(tC.selected...)
+			if tC.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tC.wantErr) {
+					t.Errorf("expected `%v` to contain `%s`", err, tC.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(tC.wantPackages, p.packages) {
+				t.Errorf("expected packages: `%v` got: `%v`", tC.wantPackages, p.packages)
+			}
+			if !reflect.DeepEqual(tC.wantEnv, p.env) {
+				t.Errorf("expected env: `%v` got: `%v`", tC.wantEnv, p.env)
+			}
+			if got := p.featureNames(); len(tC.wantFeatures) != 0 && !reflect.DeepEqual(tC.wantFeatures, got) {
+				t.Errorf("expected features: `%v` got: `%v`", tC.wantFeatures, got)
+			}
+
+			var routes strings.Builder
+			if err := p.templates.ExecuteTemplate(&routes, "Routes", nil); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if tC.wantRoutes != routes.String() {
+				t.Errorf("expected routes: `%s` got: `%s`", tC.wantRoutes, routes.String())
+			}
+		})
+	}
+}
@@ -9,7 +9,7 @@ func Test_findDatabase(t *testing.T) {
 	testCases := []struct {
 		name    string
 		search  string
-		want    Database
+		want    string
 		wantErr string
 	}{
 		{
@@ -20,17 +20,17 @@ func Test_findDatabase(t *testing.T) {
 		{
 			name:   "exact name",
 			search: "mysql",
-			want:   databases["mysql"],
+			want:   "mysql",
 		},
 		{
 			name:   "exact match",
 			search: "pg",
-			want:   databases["postgres"],
+			want:   "postgres",
 		},
 		{
 			name:   "different case",
 			search: "postgreSQL",
-			want:   databases["postgres"],
+			want:   "postgres",
 		},
 		{
 			name:    "not found",
@@ -40,15 +40,15 @@ func Test_findDatabase(t *testing.T) {
 	}
 	for _, tC := range testCases {
 		t.Run(tC.name, func(t *testing.T) {
-			want, err := findDatabase(tC.search)
+			want, err := findComponent[Database](tC.search)
 			if tC.wantErr != "" {
 				if !strings.Contains(err.Error(), tC.wantErr) {
 					t.Errorf("expected `%s` to contain `%s`", err.Error(), tC.wantErr)
 				}
 				return
 			}
-			if tC.want.Name != want.Name {
-				t.Errorf("expected: `%s` got: `%s`", tC.want.Name, want.Name)
+			if tC.want != want.Name {
+				t.Errorf("expected: `%s` got: `%s`", tC.want, want.Name)
 			}
 		})
 	}
@@ -0,0 +1,34 @@
+package src
+
+import (
+	"slices"
+	"strings"
+)
+
+// Feature is an optional module of the generated app, like metrics, enabled by name.
+type Feature struct {
+	ComponentInfo `yaml:",inline" mapstructure:",squash"`
+}
+
+func (f *Feature) Kind() string {
+	return kindFeature
+}
+
+func (f *Feature) Info() *ComponentInfo {
+	return &f.ComponentInfo
+}
+
+func (f *Feature) goPackages() []string {
+	return f.Packages
+}
+
+// FeatureSet is the names of the enabled features, including the ones they require.
+type FeatureSet []string
+
+// Has reports whether the feature called name is enabled, for templates to use
+// as {{ if .Features.Has "metrics" }}.
+func (s FeatureSet) Has(name string) bool {
+	return slices.ContainsFunc(s, func(f string) bool {
+		return strings.EqualFold(name, f)
+	})
+}
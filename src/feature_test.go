@@ -0,0 +1,41 @@
+package src
+
+import "testing"
+
+func Test_FeatureSet_Has(t *testing.T) {
+	testCases := []struct {
+		name     string
+		features FeatureSet
+		has      string
+		want     bool
+	}{
+		{
+			name: "none",
+			has:  "metrics",
+		},
+		{
+			name:     "enabled",
+			features: FeatureSet{"auth", "metrics"},
+			has:      "metrics",
+			want:     true,
+		},
+		{
+			name:     "any case",
+			features: FeatureSet{"metrics"},
+			has:      "Metrics",
+			want:     true,
+		},
+		{
+			name:     "not enabled",
+			features: FeatureSet{"auth"},
+			has:      "metrics",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			if got := tC.features.Has(tC.has); tC.want != got {
+				t.Errorf("expected: `%t` got: `%t`", tC.want, got)
+			}
+		})
+	}
+}
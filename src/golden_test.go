@@ -0,0 +1,426 @@
+package src
+
+import (
+	"bytes"
+	"flag"
+	"io/fs"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "regenerate the golden files in testdata/golden")
+
+// Test_Generate_golden generates projects for a representative set of configurations,
+// with the go toolchain stubbed out, and compares them to testdata/golden/<name>.
+// The commands that would have been run are kept in testdata/golden/<name>.commands.
+func Test_Generate_golden(t *testing.T) {
+	pack, err := filepath.Abs("testdata/pack")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	petstore, err := filepath.Abs("testdata/openapi/petstore.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name     string
+		router   string
+		orm      string
+		database string
+		project  Project
+	}{
+		{
+			name:     "gin-postgres",
+			router:   "gin",
+			orm:      "gorm",
+			database: "postgres",
+			project: Project{
+				AppName:  "Gin App",
+				PkgName:  "example.com/gin",
+				Folder:   "application",
+				License:  "mit",
+				Docker:   true,
+				Sentry:   true,
+				Header:   true,
+				Features: []string{"metrics"},
+			},
+		},
+		{
+			name:     "echo-mysql",
+			router:   "echo",
+			orm:      "gorm",
+			database: "mysql",
+			project: Project{
+				AppName:    "Echo App",
+				PkgName:    "example.com/echo",
+				Folder:     "application",
+				EnvPrefix:  "echo",
+				APIVersion: "v2",
+			},
+		},
+		{
+			name:     "mux-mariadb-no-folder",
+			router:   "mux",
+			orm:      "gorm",
+			database: "mariadb",
+			project: Project{
+				AppName: "Mux App",
+				PkgName: "example.com/mux",
+				License: "apache",
+				Docker:  true,
+			},
+		},
+		{
+			name:     "http-postgres",
+			router:   "http",
+			orm:      "gorm",
+			database: "postgres",
+			project: Project{
+				AppName:  "HTTP App",
+				PkgName:  "example.com/http",
+				Folder:   "application",
+				Features: []string{"metrics"},
+			},
+		},
+		{
+			name:     "chi-mysql",
+			router:   "chi",
+			orm:      "gorm",
+			database: "mysql",
+			project: Project{
+				AppName: "Chi App",
+				PkgName: "example.com/chi",
+				Folder:  "application",
+				License: "bsd",
+				Header:  true,
+			},
+		},
+		{
+			name:     "fiber-postgres",
+			router:   "fiber",
+			orm:      "gorm",
+			database: "postgres",
+			project: Project{
+				AppName:  "Fiber App",
+				PkgName:  "example.com/fiber",
+				Folder:   "application",
+				Docker:   true,
+				Features: []string{"metrics", "openapi"},
+			},
+		},
+		{
+			name:     "echo-openapi",
+			router:   "echo",
+			orm:      "gorm",
+			database: "postgres",
+			project: Project{
+				AppName:     "Petstore",
+				PkgName:     "example.com/petstore",
+				Folder:      "application",
+				Features:    []string{"openapi"},
+				FromOpenAPI: petstore,
+			},
+		},
+		{
+			name:     "grpc",
+			router:   "gin",
+			orm:      "gorm",
+			database: "postgres",
+			project: Project{
+				AppName:   "Widgets",
+				PkgName:   "example.com/widgets",
+				Folder:    "application",
+				Docker:    true,
+				Transport: transportGRPC,
+			},
+		},
+		{
+			name:     "chi-both",
+			router:   "chi",
+			orm:      "gorm",
+			database: "mysql",
+			project: Project{
+				AppName:  "Chi gRPC",
+				PkgName:  "example.com/chigrpc",
+				Folder:   "application",
+				Docker:   true,
+				Features: []string{"grpc"},
+			},
+		},
+		{
+			name:     "http-graphql",
+			router:   "http",
+			orm:      "gorm",
+			database: "postgres",
+			project: Project{
+				AppName:  "Widgets",
+				PkgName:  "example.com/widgets",
+				Folder:   "application",
+				Features: []string{"graphql", "openapi"},
+			},
+		},
+		{
+			name:     "mux-realtime",
+			router:   "mux",
+			orm:      "gorm",
+			database: "postgres",
+			project: Project{
+				AppName:  "Notifier",
+				PkgName:  "example.com/notifier",
+				Folder:   "application",
+				Features: []string{"realtime"},
+			},
+		},
+		{
+			name:     "gin-auth",
+			router:   "gin",
+			orm:      "gorm",
+			database: "mysql",
+			project: Project{
+				AppName:   "Secure",
+				PkgName:   "example.com/secure",
+				Folder:    "application",
+				EnvPrefix: "SECURE",
+				Features:  []string{"auth", "openapi"},
+			},
+		},
+		{
+			name:     "echo-oidc",
+			router:   "echo",
+			orm:      "gorm",
+			database: "postgres",
+			project: Project{
+				AppName:  "Portal",
+				PkgName:  "example.com/portal",
+				Folder:   "application",
+				Docker:   true,
+				Features: []string{"oidc"},
+			},
+		},
+		{
+			name:     "chi-authz",
+			router:   "chi",
+			orm:      "gorm",
+			database: "postgres",
+			project: Project{
+				AppName:  "Newsroom",
+				PkgName:  "example.com/newsroom",
+				Folder:   "application",
+				Features: []string{"auth", "authz"},
+			},
+		},
+		{
+			name:     "fiber-ratelimit",
+			router:   "fiber",
+			orm:      "gorm",
+			database: "postgres",
+			project: Project{
+				AppName:  "Gateway",
+				PkgName:  "example.com/gateway",
+				Folder:   "application",
+				Docker:   true,
+				Features: []string{"auth", "ratelimit"},
+			},
+		},
+		{
+			name:     "custom",
+			router:   "gin",
+			orm:      "gorm",
+			database: "pg",
+			project: Project{
+				AppName: "Custom App",
+				PkgName: "example.com/custom",
+				Folder:  "service",
+				License: "mit",
+				Packs:   []string{pack},
+				Templates: map[string]string{
+					"__application__/OWNERS": "{{ .Vars.team }}\n",
+				},
+				Blocks: map[string]string{
+					"header.template": "// Owned by {{ .Vars.team }}\n\n",
+				},
+				Vars: map[string]any{
+					"team":    "payments",
+					"regions": []any{"us", "eu"},
+				},
+				Set: []string{"oncall=true"},
+			},
+		},
+	}
+
+	log.SetOutput(&bytes.Buffer{})
+	defer log.SetOutput(os.Stderr)
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.Chdir(dir); err != nil {
+				t.Fatal(err)
+			}
+			defer os.Chdir(wd)
+
+			var commands bytes.Buffer
+			p := tC.project
+			p.Router.Name = tC.router
+			p.ORM.Name = tC.orm
+			p.Database.Name = tC.database
+			p.Version = "1.22"
+			p.packages = NewProject().packages
+			p.Templates = maps.Clone(tC.project.Templates)
+			p.Vars = maps.Clone(tC.project.Vars)
+			p.absolutePath = dir
+			p.out = diskWriter{}
+			p.run = func(name string, args ...string) error {
+				commands.WriteString(strings.Join(append([]string{name}, args...), " ") + "\n")
+				return nil
+			}
+
+			if err := p.Generate(); err != nil {
+				t.Fatalf("unable to generate: %s", err)
+			}
+
+			golden := filepath.Join(wd, "testdata", "golden", tC.name)
+			got := readTree(t, dir)
+			got[".commands"] = commands.Bytes()
+
+			if *update {
+				writeGolden(t, golden, got)
+				copyExecutable(t, dir, golden)
+				return
+			}
+
+			want := readTree(t, golden)
+			want[".commands"], err = os.ReadFile(golden + ".commands")
+			if err != nil {
+				t.Fatalf("missing golden files, run `go test ./src -run Test_Generate_golden -update`: %s", err)
+			}
+
+			for _, name := range sortedKeys(want) {
+				if _, ok := got[name]; !ok {
+					t.Errorf("missing file: %s", name)
+				}
+			}
+
+			for _, name := range sortedKeys(got) {
+				w, ok := want[name]
+				if !ok {
+					t.Errorf("unexpected file: %s", name)
+					continue
+				}
+
+				if line, ok := firstDifference(w, got[name]); !ok {
+					t.Errorf("%s differs from golden file at line %d", name, line)
+				}
+				if name != ".commands" && executable(t, filepath.Join(dir, filepath.FromSlash(name))) != executable(t, filepath.Join(golden, filepath.FromSlash(name))) {
+					t.Errorf("%s is executable: %t, unlike the golden file", name, executable(t, filepath.Join(dir, filepath.FromSlash(name))))
+				}
+			}
+		})
+	}
+}
+
+// readTree reads every file under dir, keyed by its slash separated relative path.
+func readTree(t *testing.T, dir string) map[string][]byte {
+	t.Helper()
+
+	files := map[string][]byte{}
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return files
+	}
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		files[filepath.ToSlash(rel)], err = os.ReadFile(path)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return files
+}
+
+// executable reports whether the file at path can be run by its owner, which is
+// all of its permissions git keeps
+func executable(t *testing.T, path string) bool {
+	t.Helper()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return info.Mode().Perm()&0o100 != 0
+}
+
+// copyExecutable makes the files in golden executable when they are in dir.
+func copyExecutable(t *testing.T, dir, golden string) {
+	t.Helper()
+
+	for name := range readTree(t, dir) {
+		if executable(t, filepath.Join(dir, filepath.FromSlash(name))) {
+			if err := os.Chmod(filepath.Join(golden, filepath.FromSlash(name)), 0o755); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+}
+
+// writeGolden replaces the golden tree and commands with files.
+func writeGolden(t *testing.T, dir string, files map[string][]byte) {
+	t.Helper()
+
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, contents := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if name == ".commands" {
+			path = dir + ".commands"
+		}
+
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, contents, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// firstDifference returns the first line where want and got differ.
+func firstDifference(want, got []byte) (int, bool) {
+	if bytes.Equal(want, got) {
+		return 0, true
+	}
+
+	w, g := bytes.Split(want, []byte("\n")), bytes.Split(got, []byte("\n"))
+	for i := range w {
+		if i >= len(g) || !bytes.Equal(w[i], g[i]) {
+			return i + 1, false
+		}
+	}
+
+	return len(w) + 1, false
+}
@@ -0,0 +1,77 @@
+package src
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// formatImports writes an import declaration the way gofmt would leave it,
+// with the standard library grouped first, for templates as {{ imports ... }}.
+// Each argument is a path, a list of paths, or paths separated by whitespace,
+// like a block rendered with include. Duplicate and empty paths are left out,
+// as is false, for optional paths like (and .Transport.HTTP "net/http").
+func formatImports(args ...any) (string, error) {
+	var paths []string
+	for _, arg := range args {
+		switch a := arg.(type) {
+		case string:
+			paths = append(paths, strings.Fields(a)...)
+		case []string:
+			paths = append(paths, a...)
+		case bool:
+			if a {
+				return "", fmt.Errorf("unable to import %v, expected a path or list of paths", arg)
+			}
+		case []any:
+			for _, v := range a {
+				paths = append(paths, fmt.Sprint(v))
+			}
+		default:
+			return "", fmt.Errorf("unable to import %v, expected a path or list of paths", arg)
+		}
+	}
+
+	var std, other []string
+	for _, path := range paths {
+		path = strings.Trim(path, `"`)
+		switch {
+		case path == "", slices.Contains(std, path), slices.Contains(other, path):
+		case isStdLib(path):
+			std = append(std, path)
+		default:
+			other = append(other, path)
+		}
+	}
+	slices.Sort(std)
+	slices.Sort(other)
+
+	switch len(std) + len(other) {
+	case 0:
+		return "", nil
+	case 1:
+		return fmt.Sprintf("import %q", append(std, other...)[0]), nil
+	}
+
+	var b strings.Builder
+	b.WriteString("import (\n")
+	for i, group := range [][]string{std, other} {
+		if i > 0 && len(std) > 0 && len(group) > 0 {
+			b.WriteString("\n")
+		}
+
+		for _, path := range group {
+			fmt.Fprintf(&b, "\t%q\n", path)
+		}
+	}
+	b.WriteString(")")
+
+	return b.String(), nil
+}
+
+// isStdLib reports whether path is in the standard library,
+// which unlike other modules has no dot in its first element.
+func isStdLib(path string) bool {
+	first, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(first, ".")
+}
@@ -0,0 +1,79 @@
+package src
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_formatImports(t *testing.T) {
+	testCases := []struct {
+		name    string
+		args    []any
+		want    string
+		wantErr string
+	}{
+		{
+			name: "none",
+			args: []any{"", []string{}},
+			want: "",
+		},
+		{
+			name: "one",
+			args: []any{"net/http"},
+			want: `import "net/http"`,
+		},
+		{
+			name: "standard library first",
+			args: []any{"github.com/gin-gonic/gin", []string{"net/http", "encoding/json"}},
+			want: "import (\n\t\"encoding/json\"\n\t\"net/http\"\n\n\t\"github.com/gin-gonic/gin\"\n)",
+		},
+		{
+			name: "only modules",
+			args: []any{"github.com/stretchr/testify/require", "example.com/app/metrics"},
+			want: "import (\n\t\"example.com/app/metrics\"\n\t\"github.com/stretchr/testify/require\"\n)",
+		},
+		{
+			name: "rendered block",
+			args: []any{"\n\t\"example.com/app/metrics\"\n\texample.com/app/auth\n", "example.com/app/metrics"},
+			want: "import (\n\t\"example.com/app/auth\"\n\t\"example.com/app/metrics\"\n)",
+		},
+		{
+			name: "from vars",
+			args: []any{[]any{"os", "log"}},
+			want: "import (\n\t\"log\"\n\t\"os\"\n)",
+		},
+		{
+			name: "optional",
+			args: []any{"net/http", false, "log"},
+			want: "import (\n\t\"log\"\n\t\"net/http\"\n)",
+		},
+		{
+			name:    "true",
+			args:    []any{true},
+			wantErr: "unable to import true",
+		},
+		{
+			name:    "not a path",
+			args:    []any{3},
+			wantErr: "unable to import 3",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			got, err := formatImports(tC.args...)
+			if tC.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tC.wantErr) {
+					t.Errorf("expected `%v` to contain `%s`", err, tC.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if tC.want != got {
+				t.Errorf("expected: `%s` got: `%s`", tC.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package src
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Combination is one set of choices a project can be generated with.
+type Combination struct {
+	Router   string
+	ORM      string
+	Database string
+	License  string
+	Docker   bool
+	Sentry   bool
+	Header   bool
+}
+
+func (c Combination) String() string {
+	return fmt.Sprintf("router=%s orm=%s database=%s license=%s docker=%t sentry=%t header=%t",
+		c.Router, c.ORM, c.Database, c.License, c.Docker, c.Sentry, c.Header)
+}
+
+// LintIssue is a problem found in the output of a template.
+// Warnings are for output that is valid, but not formatted.
+type LintIssue struct {
+	Combination Combination
+	File        string
+	Line        int
+	Template    string
+	Message     string
+	Warning     bool
+}
+
+func (i LintIssue) String() string {
+	switch {
+	case i.File == "":
+		return i.Message
+	case i.Line == 0:
+		return fmt.Sprintf("%s (from %s): %s", i.File, i.Template, i.Message)
+	}
+
+	return fmt.Sprintf("%s:%d (from %s): %s", i.File, i.Line, i.Template, i.Message)
+}
+
+// Combinations lists every combination of the known components and options.
+func Combinations() []Combination {
+	var combos []Combination
+	for _, r := range components.names(kindRouter) {
+		for _, o := range components.names(kindORM) {
+			for _, db := range components.names(kindDatabase) {
+				for _, l := range sortedKeys(licenses) {
+					for _, flags := range [][3]bool{
+						{false, false, false}, {false, false, true}, {false, true, false}, {false, true, true},
+						{true, false, false}, {true, false, true}, {true, true, false}, {true, true, true},
+					} {
+						combos = append(combos, Combination{
+							Router:   r,
+							ORM:      o,
+							Database: db,
+							License:  l,
+							Docker:   flags[0],
+							Sentry:   flags[1],
+							Header:   flags[2],
+						})
+					}
+				}
+			}
+		}
+	}
+
+	return combos
+}
+
+// Lint generates the project in memory for each combination, using the
+// templates, packs, blocks and variables of base, and checks the output.
+// Combinations the selected components can't be used with are skipped.
+func Lint(base Project, combos []Combination) (issues []LintIssue, skipped int) {
+	for _, c := range combos {
+		p := base
+		p.Router, p.ORM, p.Database = Router{}, ORM{}, Database{}
+		p.Router.Name = c.Router
+		p.ORM.Name = c.ORM
+		p.Database.Name = c.Database
+		p.License = c.License
+		p.Docker = c.Docker
+		p.Sentry = c.Sentry
+		p.Header = c.Header
+		p.packages = slices.Clone(base.packages)
+		p.Templates = maps.Clone(base.Templates)
+		p.Vars = maps.Clone(base.Vars)
+
+		files := memWriter{}
+		p.out = files
+
+		if err := p.setup(); err != nil {
+			var incompatible incompatibleError
+			if errors.As(err, &incompatible) {
+				skipped++
+				continue
+			}
+
+			issues = append(issues, LintIssue{Combination: c, Message: err.Error()})
+			continue
+		}
+
+		if err := p.makeFiles(); err != nil {
+			issues = append(issues, LintIssue{Combination: c, Message: err.Error()})
+			continue
+		}
+
+		for _, i := range lintFiles(files) {
+			i.Combination = c
+			issues = append(issues, i)
+		}
+	}
+
+	return issues, skipped
+}
+
+// lintFiles checks that Go files parse, are formatted and agree on their
+// package name with the rest of their folder, and that YAML files are valid.
+func lintFiles(files memWriter) []LintIssue {
+	var issues []LintIssue
+	issue := func(name string, line int, warning bool, format string, a ...any) {
+		issues = append(issues, LintIssue{
+			File:     name,
+			Line:     line,
+			Template: files[name].Source,
+			Message:  fmt.Sprintf(format, a...),
+			Warning:  warning,
+		})
+	}
+
+	packages := map[string]string{}
+	for _, name := range sortedKeys(files) {
+		contents := files[name].Contents
+
+		switch path.Ext(name) {
+		case ".go":
+			f, err := parser.ParseFile(token.NewFileSet(), name, contents, parser.AllErrors)
+			if list, ok := err.(scanner.ErrorList); ok && len(list) > 0 {
+				// Later errors are usually caused by the first
+				issue(name, list[0].Pos.Line, false, "%s", list[0].Msg)
+				continue
+			} else if err != nil {
+				issue(name, 0, false, "%s", err)
+				continue
+			}
+
+			pkg := strings.TrimSuffix(f.Name.Name, "_test")
+			if prev, ok := packages[path.Dir(name)]; ok && prev != pkg {
+				issue(name, 0, false, "package %s does not match package %s in the same folder", f.Name.Name, prev)
+			}
+			packages[path.Dir(name)] = pkg
+
+			if formatted, err := format.Source(contents); err == nil && !bytes.Equal(formatted, contents) {
+				issue(name, 0, true, "not gofmt formatted")
+			}
+		case ".yml", ".yaml":
+			var v any
+			if err := yaml.Unmarshal(contents, &v); err != nil {
+				issue(name, 0, false, "%s", err)
+			}
+		}
+	}
+
+	return issues
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,155 @@
+package src
+
+import (
+	"io"
+	"log"
+	"os"
+	"strings"
+	"testing"
+)
+
+func Test_lintFiles(t *testing.T) {
+	testCases := []struct {
+		name        string
+		files       map[string]string
+		wantMessage string
+		wantWarning bool
+	}{
+		{
+			name: "valid",
+			files: map[string]string{
+				"app/app.go":      "package app\n",
+				"app/app_test.go": "package app_test\n",
+				"compose.yml":     "services:\n  app:\n    build: ./\n",
+			},
+		},
+		{
+			name:        "invalid go",
+			files:       map[string]string{"app/app.go": "package app\n\nfunc App() {{ *mux.Router }} {}\n"},
+			wantMessage: "expected",
+		},
+		{
+			name: "mismatched package",
+			files: map[string]string{
+				"models/model.go":      "package model\n",
+				"models/model_test.go": "package models\n",
+			},
+			wantMessage: "does not match package",
+		},
+		{
+			name:        "not formatted",
+			files:       map[string]string{"app/app.go": "package app\nvar  x = 1\n"},
+			wantMessage: "not gofmt formatted",
+			wantWarning: true,
+		},
+		{
+			name:        "invalid yaml",
+			files:       map[string]string{"docker-compose.yml": "services:\n  app:\n   build: ./\n  - db\n"},
+			wantMessage: "yaml",
+		},
+		{
+			name:  "other files are ignored",
+			files: map[string]string{"README.md": "{{ oops"},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			files := memWriter{}
+			for name, contents := range tC.files {
+				files.WriteFile(name, name+ext, []byte(contents), 0o640)
+			}
+
+			issues := lintFiles(files)
+			if tC.wantMessage == "" {
+				if len(issues) != 0 {
+					t.Errorf("expected no issues, got: %v", issues)
+				}
+				return
+			}
+
+			if len(issues) != 1 {
+				t.Fatalf("expected 1 issue, got: %v", issues)
+			}
+			if !strings.Contains(issues[0].Message, tC.wantMessage) {
+				t.Errorf("expected `%s` to contain `%s`", issues[0].Message, tC.wantMessage)
+			}
+			if tC.wantWarning != issues[0].Warning {
+				t.Errorf("expected warning: `%t` got: `%t`", tC.wantWarning, issues[0].Warning)
+			}
+		})
+	}
+}
+
+func Test_Lint(t *testing.T) {
+	if testing.Short() {
+		t.Skip("renders every combination")
+	}
+
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	combos := Combinations()
+	issues, skipped := Lint(NewProject(), combos)
+	for _, i := range issues {
+		t.Errorf("%s: %s", i.Combination, i)
+	}
+	if skipped != 0 {
+		t.Errorf("skipped %d combinations of the built-in components", skipped)
+	}
+
+	// Features are linted with a sample of the combinations, as rendering every one of
+	// them for every feature is slow; MAKEGO_LINT_ALL=1 lints them all
+	all := os.Getenv("MAKEGO_LINT_ALL") != ""
+	for _, f := range components.names(kindFeature) {
+		total := 0
+		for _, transport := range transports {
+			base := NewProject()
+			base.Features = []string{f}
+			base.Transport = transport
+
+			var issues []LintIssue
+			linted := 0
+			if all {
+				var skipped int
+				issues, skipped = Lint(base, combos)
+				linted = len(combos) - skipped
+			} else {
+				issues, linted = lintSample(base, combos)
+			}
+
+			for _, i := range issues {
+				t.Errorf("feature=%s transport=%s %s: %s", f, transport, i.Combination, i)
+			}
+			total += linted
+		}
+		if total == 0 {
+			t.Errorf("feature=%s: skipped every combination", f)
+		}
+	}
+}
+
+// lintSample lints base with a combination for each router, and for each ORM and
+// database, with every option on so the most is rendered. Combinations base can't
+// be used with are skipped in favour of the next that covers the same choices.
+func lintSample(base Project, combos []Combination) (issues []LintIssue, linted int) {
+	covered := map[string]bool{}
+	for _, c := range combos {
+		if !c.Docker || !c.Sentry || !c.Header {
+			continue
+		}
+
+		keys := []string{"router=" + c.Router, "orm=" + c.ORM + " database=" + c.Database}
+		if covered[keys[0]] && covered[keys[1]] {
+			continue
+		}
+
+		found, skipped := Lint(base, []Combination{c})
+		issues = append(issues, found...)
+		if skipped == 0 {
+			linted++
+			covered[keys[0]], covered[keys[1]] = true, true
+		}
+	}
+
+	return issues, linted
+}
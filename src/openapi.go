@@ -0,0 +1,849 @@
+package src
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// API is what the spec component generates code for, read from an OpenAPI 3 document.
+type API struct {
+	Title string
+	// The OpenAPI version of the document, and the document itself as JSON,
+	// which the openapi feature serves along with the app's other routes
+	OpenAPI  string
+	Document string
+	Types    []Type
+	Services []Service
+	// Whether any type uses time.Time, to import it
+	UsesTime bool
+}
+
+// Type is a Go type for a schema, a struct when it has fields.
+type Type struct {
+	Name   string
+	Doc    string
+	Fields []Field
+	// The underlying type, when it isn't a struct
+	Alias string
+}
+
+// Field is a property of a struct Type.
+type Field struct {
+	Name string
+	Type string
+	JSON string
+	Doc  string
+}
+
+// Service is the operations sharing a tag, with an interface for the app to implement.
+type Service struct {
+	Tag string
+	// Name of the interface, like PetsService
+	Name string
+	// Name of the stub implementing it in actions, like petsService
+	Impl       string
+	Operations []Operation
+}
+
+// Operation is a method and path, handled by a method of its Service.
+type Operation struct {
+	Name    string
+	Method  string
+	Path    string
+	Summary string
+	Params  []Param
+	// Go type of the JSON request body, if any
+	Body string
+	// Go type of the JSON response, if any, and the status of a successful response
+	Result string
+	Status int
+}
+
+// Param is a path, query or header parameter of an Operation.
+type Param struct {
+	Name     string
+	Key      string
+	In       string
+	Type     string
+	Required bool
+}
+
+// Imports are the packages the generated types and interfaces use, besides the ones they always do.
+func (a *API) Imports() []string {
+	var imports []string
+	if len(a.Services) > 0 {
+		imports = append(imports, "context")
+	}
+	if a.UsesTime {
+		imports = append(imports, "time")
+	}
+
+	return imports
+}
+
+// Qualify refers to the generated types in t from another package, like []api.Pet.
+func (a *API) Qualify(t string) string {
+	return typeName.ReplaceAllStringFunc(t, func(name string) string {
+		// Names from other packages, like time.Time, are left alone
+		if !strings.Contains(name, ".") && slices.ContainsFunc(a.Types, func(t Type) bool { return t.Name == name }) {
+			return "api." + name
+		}
+		return name
+	})
+}
+
+// Sample is a path with example values for its parameters, and the required
+// query parameters, to send requests to op in tests.
+func (op Operation) Sample() string {
+	path := op.Path
+	var query []string
+	for _, p := range op.Params {
+		switch {
+		case p.In == "path":
+			path = strings.ReplaceAll(path, "{"+p.Key+"}", sampleValue(p.Type))
+		case p.In == "query" && p.Required:
+			query = append(query, p.Key+"="+sampleValue(p.Type))
+		}
+	}
+
+	if len(query) > 0 {
+		path += "?" + strings.Join(query, "&")
+	}
+
+	return path
+}
+
+// SampleBody is a JSON request body that decodes into op's Body, if it has one.
+func (op Operation) SampleBody() string {
+	switch {
+	case op.Body == "":
+		return ""
+	case strings.HasPrefix(op.Body, "[]"):
+		return "[]"
+	case op.Body == "string":
+		return `""`
+	case op.Body == "bool":
+		return "false"
+	case slices.Contains(paramTypes, op.Body):
+		return "0"
+	default:
+		return "{}"
+	}
+}
+
+// StatusCode is the constant for the status of a successful response, like http.StatusCreated.
+func (op Operation) StatusCode() string {
+	if name, ok := successStatuses[op.Status]; ok {
+		return "http." + name
+	}
+
+	return strconv.Itoa(op.Status)
+}
+
+var successStatuses = map[int]string{
+	http.StatusOK:                   "StatusOK",
+	http.StatusCreated:              "StatusCreated",
+	http.StatusAccepted:             "StatusAccepted",
+	http.StatusNonAuthoritativeInfo: "StatusNonAuthoritativeInfo",
+	http.StatusNoContent:            "StatusNoContent",
+	http.StatusResetContent:         "StatusResetContent",
+	http.StatusPartialContent:       "StatusPartialContent",
+}
+
+func sampleValue(t string) string {
+	switch t {
+	case "string":
+		return "x"
+	case "bool":
+		return "true"
+	default:
+		return "1"
+	}
+}
+
+var typeName = regexp.MustCompile(`[\w.]+`)
+
+// paramTypes are the types parameters can be parsed to, anything else is kept as a string.
+var paramTypes = []string{"string", "bool", "int", "int32", "int64", "float32", "float64"}
+
+// The parts of an OpenAPI document that code is generated from
+type openAPIDoc struct {
+	OpenAPI string `yaml:"openapi"`
+	Info    struct {
+		Title string
+	}
+	Paths      ordered[pathItem]
+	Components struct {
+		Schemas       ordered[*schema]
+		Parameters    map[string]*parameter
+		RequestBodies map[string]*requestBody `yaml:"requestBodies"`
+		Responses     map[string]*response
+	}
+}
+
+type pathItem struct {
+	Parameters []*parameter
+	Get        *operation
+	Put        *operation
+	Post       *operation
+	Delete     *operation
+	Options    *operation
+	Head       *operation
+	Patch      *operation
+}
+
+type operation struct {
+	OperationID string `yaml:"operationId"`
+	Summary     string
+	Tags        []string
+	Parameters  []*parameter
+	RequestBody *requestBody `yaml:"requestBody"`
+	Responses   map[string]*response
+}
+
+type parameter struct {
+	Ref      string `yaml:"$ref"`
+	Name     string
+	In       string
+	Required bool
+	Schema   *schema
+}
+
+type requestBody struct {
+	Ref     string `yaml:"$ref"`
+	Content map[string]mediaType
+}
+
+type response struct {
+	Ref     string `yaml:"$ref"`
+	Content map[string]mediaType
+}
+
+type mediaType struct {
+	Schema *schema
+}
+
+type schema struct {
+	Ref                  string `yaml:"$ref"`
+	Type                 schemaType
+	Format               string
+	Description          string
+	Required             []string
+	Properties           ordered[*schema]
+	Items                *schema
+	AdditionalProperties *additionalProperties `yaml:"additionalProperties"`
+	AllOf                []*schema             `yaml:"allOf"`
+}
+
+// schemaType is the type of a schema, which OpenAPI 3.1 can give as a list, like
+// [string, "null"]. A null member makes it nullable, and several others make it any.
+type schemaType struct {
+	Name     string
+	Nullable bool
+}
+
+func (t *schemaType) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.SequenceNode {
+		return node.Decode(&t.Name)
+	}
+
+	var names []string
+	if err := node.Decode(&names); err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		switch {
+		case name == "null":
+			t.Nullable = true
+		case t.Name == "":
+			t.Name = name
+		case t.Name != name:
+			t.Name = "any"
+		}
+	}
+
+	return nil
+}
+
+// additionalProperties is the schema of the values of an object's other properties,
+// or whether it can have any, as either is allowed
+type additionalProperties struct {
+	Allowed bool
+	Schema  *schema
+}
+
+func (a *additionalProperties) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!bool" {
+		return node.Decode(&a.Allowed)
+	}
+
+	a.Allowed = true
+	return node.Decode(&a.Schema)
+}
+
+// ordered is a YAML mapping that keeps the order of its keys.
+type ordered[T any] struct {
+	keys   []string
+	values map[string]T
+}
+
+func (o *ordered[T]) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: expected a mapping", node.Line)
+	}
+
+	o.values = map[string]T{}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		var v T
+		if err := node.Content[i+1].Decode(&v); err != nil {
+			return err
+		}
+
+		key := node.Content[i].Value
+		o.keys = append(o.keys, key)
+		o.values[key] = v
+	}
+
+	return nil
+}
+
+// LoadAPI reads the OpenAPI 3.0 or 3.1 document in file, which can be YAML or JSON.
+func LoadAPI(file string) (*API, error) {
+	contents, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc openAPIDoc
+	if err := yaml.Unmarshal(contents, &doc); err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %w", file, err)
+	}
+
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("%s is not an OpenAPI 3 document", file)
+	}
+
+	api, err := newAPIBuilder(&doc).build()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s: %w", file, err)
+	}
+
+	if api.Document, err = documentJSON(contents); err != nil {
+		return nil, fmt.Errorf("unable to convert %s to JSON: %w", file, err)
+	}
+
+	return api, nil
+}
+
+// documentJSON converts an OpenAPI document, in YAML or JSON, to indented JSON
+func documentJSON(contents []byte) (string, error) {
+	var doc any
+	if err := yaml.Unmarshal(contents, &doc); err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(jsonValue(doc)); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// jsonValue makes v, decoded from YAML, encodable as JSON, which only has
+// string keys, like the unquoted status codes of responses
+func jsonValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, e := range v {
+			v[k] = jsonValue(e)
+		}
+	case map[any]any:
+		m := make(map[string]any, len(v))
+		for k, e := range v {
+			m[fmt.Sprint(k)] = jsonValue(e)
+		}
+		return m
+	case []any:
+		for i, e := range v {
+			v[i] = jsonValue(e)
+		}
+	}
+
+	return v
+}
+
+// apiBuilder turns an OpenAPI document into an API, naming the types it needs on the way.
+type apiBuilder struct {
+	doc      *openAPIDoc
+	api      *API
+	services map[string]*Service
+	// operations are the operationIds by the Go name they were given
+	operations map[string]string
+	// schemas are the schemas of the types by name, and refs the type names of the components
+	schemas map[string]*schema
+	refs    map[string]string
+}
+
+func newAPIBuilder(doc *openAPIDoc) *apiBuilder {
+	return &apiBuilder{
+		doc:        doc,
+		api:        &API{Title: doc.Info.Title, OpenAPI: doc.OpenAPI},
+		services:   map[string]*Service{},
+		operations: map[string]string{},
+		schemas:    map[string]*schema{},
+		refs:       map[string]string{},
+	}
+}
+
+func (b *apiBuilder) build() (*API, error) {
+	for _, name := range b.doc.Components.Schemas.keys {
+		if _, err := b.componentType(name); err != nil {
+			return nil, fmt.Errorf("schema %s: %w", name, err)
+		}
+	}
+
+	for _, path := range b.doc.Paths.keys {
+		item := b.doc.Paths.values[path]
+		methods := []struct {
+			method string
+			op     *operation
+		}{
+			{http.MethodGet, item.Get},
+			{http.MethodPut, item.Put},
+			{http.MethodPost, item.Post},
+			{http.MethodDelete, item.Delete},
+			{http.MethodOptions, item.Options},
+			{http.MethodHead, item.Head},
+			{http.MethodPatch, item.Patch},
+		}
+
+		for _, m := range methods {
+			if m.op == nil {
+				continue
+			}
+
+			if err := b.addOperation(m.method, path, item.Parameters, m.op); err != nil {
+				return nil, fmt.Errorf("%s %s: %w", m.method, path, err)
+			}
+		}
+	}
+
+	names := make([]string, 0, len(b.services))
+	for name := range b.services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		b.api.Services = append(b.api.Services, *b.services[name])
+	}
+
+	return b.api, nil
+}
+
+func (b *apiBuilder) addOperation(method, path string, shared []*parameter, o *operation) error {
+	id := o.OperationID
+	if id == "" {
+		id = strings.ToLower(method) + " " + path
+	}
+
+	// The handlers of every service share a package, so their names can't repeat
+	name := goName(id)
+	if other, ok := b.operations[name]; ok {
+		return fmt.Errorf("operations %s and %s are both named %s", other, id, name)
+	}
+	b.operations[name] = id
+
+	op := Operation{
+		Name:    name,
+		Method:  method,
+		Path:    path,
+		Summary: oneLine(o.Summary),
+		Status:  http.StatusOK,
+	}
+
+	// Parameters of the operation replace the ones of its path with the same name
+	params := map[string]*parameter{}
+	var keys []string
+	for _, p := range append(slices.Clone(shared), o.Parameters...) {
+		p, err := b.parameter(p)
+		if err != nil {
+			return err
+		}
+
+		if _, ok := params[p.In+p.Name]; !ok {
+			keys = append(keys, p.In+p.Name)
+		}
+		params[p.In+p.Name] = p
+	}
+
+	for _, key := range keys {
+		p := params[key]
+		if p.In == "cookie" {
+			continue
+		}
+
+		t := "string"
+		if p.Schema != nil {
+			var err error
+			if t, err = b.goType(op.Name+goName(p.Name), p.Schema); err != nil {
+				return err
+			}
+		}
+		if !slices.Contains(paramTypes, t) {
+			t = "string"
+		}
+
+		op.Params = append(op.Params, Param{
+			Name:     goName(p.Name),
+			Key:      p.Name,
+			In:       p.In,
+			Type:     t,
+			Required: p.Required || p.In == "path",
+		})
+	}
+
+	if o.RequestBody != nil {
+		body, err := b.requestBody(o.RequestBody)
+		if err != nil {
+			return err
+		}
+
+		if s := jsonSchema(body.Content); s != nil {
+			if op.Body, err = b.goType(op.Name+"Request", s); err != nil {
+				return err
+			}
+		}
+	}
+
+	// The first successful response is the result
+	codes := make([]string, 0, len(o.Responses))
+	for code := range o.Responses {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	for _, code := range codes {
+		status, err := strconv.Atoi(code)
+		if err != nil || status < 200 || status > 299 {
+			continue
+		}
+
+		res, err := b.response(o.Responses[code])
+		if err != nil {
+			return err
+		}
+
+		op.Status = status
+		if s := jsonSchema(res.Content); s != nil {
+			if op.Result, err = b.goType(op.Name+"Response", s); err != nil {
+				return err
+			}
+		}
+		break
+	}
+
+	tag := "default"
+	if len(o.Tags) > 0 {
+		tag = o.Tags[0]
+	}
+
+	s, ok := b.services[tag]
+	if !ok {
+		name := goName(tag)
+		s = &Service{Tag: tag, Name: name + "Service", Impl: lowerFirst(name) + "Service"}
+		b.services[tag] = s
+	}
+	s.Operations = append(s.Operations, op)
+
+	return nil
+}
+
+// componentType is the type of the component schema ref, adding it the first time.
+func (b *apiBuilder) componentType(ref string) (string, error) {
+	if name, ok := b.refs[ref]; ok {
+		return name, nil
+	}
+
+	target, ok := b.doc.Components.Schemas.values[ref]
+	if !ok {
+		return "", fmt.Errorf("unknown schema %s", ref)
+	}
+
+	// Known before its type is added, so components referring to themselves end
+	name := b.typeName(goName(ref), target)
+	b.refs[ref] = name
+	return name, b.addType(name, target)
+}
+
+// typeName is name, unless a type with another schema has it already, then
+// it's numbered like name2, so an inline schema doesn't take the type of a component.
+func (b *apiBuilder) typeName(name string, s *schema) string {
+	for i := 1; ; i++ {
+		n := name
+		if i > 1 {
+			n += strconv.Itoa(i)
+		}
+
+		if other, ok := b.schemas[n]; !ok || other == s || reflect.DeepEqual(other, s) {
+			return n
+		}
+	}
+}
+
+// addType adds the named type for s, unless it's already there.
+func (b *apiBuilder) addType(name string, s *schema) error {
+	if _, ok := b.schemas[name]; ok {
+		return nil
+	}
+
+	b.schemas[name] = s
+	if s == nil {
+		s = &schema{}
+	}
+
+	t := Type{Name: name, Doc: oneLine(s.Description)}
+	if !isObject(s) || len(s.Properties.keys) == 0 {
+		// Reserve the name first, so types referring to themselves end
+		b.api.Types = append(b.api.Types, t)
+		alias, err := b.goType(name+"Item", s.withoutDescription())
+		if err != nil {
+			return err
+		}
+
+		i := slices.IndexFunc(b.api.Types, func(t Type) bool { return t.Name == name })
+		b.api.Types[i].Alias = alias
+		return nil
+	}
+
+	b.api.Types = append(b.api.Types, t)
+	var fields []Field
+	for _, prop := range s.Properties.keys {
+		ps := s.Properties.values[prop]
+		ft, err := b.goType(name+goName(prop), ps)
+		if err != nil {
+			return fmt.Errorf("property %s: %w", prop, err)
+		}
+
+		tag := prop
+		if !slices.Contains(s.Required, prop) {
+			tag += ",omitempty"
+		}
+
+		doc := ""
+		if ps != nil {
+			doc = oneLine(ps.Description)
+		}
+		fields = append(fields, Field{Name: goName(prop), Type: ft, JSON: tag, Doc: doc})
+	}
+
+	i := slices.IndexFunc(b.api.Types, func(t Type) bool { return t.Name == name })
+	b.api.Types[i].Fields = fields
+	return nil
+}
+
+// goType is the Go type for s, naming any object it defines inline after name.
+func (b *apiBuilder) goType(name string, s *schema) (string, error) {
+	if s == nil {
+		return "any", nil
+	}
+
+	if s.Ref != "" {
+		ref, ok := strings.CutPrefix(s.Ref, "#/components/schemas/")
+		if !ok {
+			return "", fmt.Errorf("unsupported reference %s", s.Ref)
+		}
+
+		return b.componentType(ref)
+	}
+
+	if len(s.AllOf) == 1 {
+		return b.goType(name, s.AllOf[0])
+	}
+
+	t, err := b.baseType(name, s)
+	if err != nil || !s.Type.Nullable {
+		return t, err
+	}
+
+	// Slices, maps and any can already be nil
+	if strings.HasPrefix(t, "[]") || strings.HasPrefix(t, "map[") || t == "any" {
+		return t, nil
+	}
+
+	return "*" + t, nil
+}
+
+// baseType is the Go type for s, whether or not it's nullable.
+func (b *apiBuilder) baseType(name string, s *schema) (string, error) {
+	switch t := s.Type.Name; {
+	case t == "array":
+		item, err := b.goType(name+"Item", s.Items)
+		return "[]" + item, err
+	case isObject(s) && len(s.Properties.keys) > 0:
+		name = b.typeName(name, s)
+		return name, b.addType(name, s)
+	case isObject(s) && s.AdditionalProperties != nil && s.AdditionalProperties.Allowed:
+		value, err := b.goType(name+"Value", s.AdditionalProperties.Schema)
+		return "map[string]" + value, err
+	case isObject(s):
+		return "map[string]any", nil
+	case t == "string" && s.Format == "date-time":
+		b.api.UsesTime = true
+		return "time.Time", nil
+	case t == "string":
+		return "string", nil
+	case t == "integer" && (s.Format == "int32" || s.Format == "int64"):
+		return s.Format, nil
+	case t == "integer":
+		return "int", nil
+	case t == "number" && s.Format == "float":
+		return "float32", nil
+	case t == "number":
+		return "float64", nil
+	case t == "boolean":
+		return "bool", nil
+	default:
+		return "any", nil
+	}
+}
+
+func (b *apiBuilder) parameter(p *parameter) (*parameter, error) {
+	return resolveRef(p, p.Ref, "#/components/parameters/", b.doc.Components.Parameters)
+}
+
+func (b *apiBuilder) requestBody(r *requestBody) (*requestBody, error) {
+	return resolveRef(r, r.Ref, "#/components/requestBodies/", b.doc.Components.RequestBodies)
+}
+
+func (b *apiBuilder) response(r *response) (*response, error) {
+	if r == nil {
+		return &response{}, nil
+	}
+
+	return resolveRef(r, r.Ref, "#/components/responses/", b.doc.Components.Responses)
+}
+
+// resolveRef returns v, or what it refers to when it is a reference to one of components.
+func resolveRef[T any](v *T, ref, prefix string, components map[string]*T) (*T, error) {
+	if ref == "" {
+		return v, nil
+	}
+
+	name, ok := strings.CutPrefix(ref, prefix)
+	if !ok {
+		return nil, fmt.Errorf("unsupported reference %s", ref)
+	}
+
+	target, ok := components[name]
+	if !ok || target == nil {
+		return nil, fmt.Errorf("unknown reference %s", ref)
+	}
+
+	return target, nil
+}
+
+func (s *schema) withoutDescription() *schema {
+	c := *s
+	c.Description = ""
+	return &c
+}
+
+func isObject(s *schema) bool {
+	return s.Type.Name == "object" || s.Type.Name == "" && (len(s.Properties.keys) > 0 || s.AdditionalProperties != nil)
+}
+
+// jsonSchema is the schema of the JSON content, if there is any.
+func jsonSchema(content map[string]mediaType) *schema {
+	for mime, m := range content {
+		if mime == "application/json" || strings.HasSuffix(mime, "+json") {
+			if m.Schema == nil {
+				return &schema{}
+			}
+			return m.Schema
+		}
+	}
+
+	return nil
+}
+
+// oneLine joins the lines of a description, to fit in a comment.
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// initialisms are written in capitals in Go names, like ID in PetID.
+var initialisms = []string{"API", "HTML", "HTTP", "ID", "JSON", "URL", "UUID"}
+
+// goName makes an exported Go name from s, like PetID from pet_id or petId.
+func goName(s string) string {
+	var words []string
+	var word []rune
+	runes := []rune(s)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if len(word) > 0 {
+				words, word = append(words, string(word)), nil
+			}
+			continue
+		}
+
+		// A new word starts with a capital after a lowercase letter
+		if unicode.IsUpper(r) && i > 0 && unicode.IsLower(runes[i-1]) && len(word) > 0 {
+			words, word = append(words, string(word)), nil
+		}
+		word = append(word, r)
+	}
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+
+	var b strings.Builder
+	for _, w := range words {
+		if i := slices.IndexFunc(initialisms, func(in string) bool { return strings.EqualFold(in, w) }); i != -1 {
+			b.WriteString(initialisms[i])
+			continue
+		}
+
+		r := []rune(w)
+		b.WriteString(string(unicode.ToUpper(r[0])) + string(r[1:]))
+	}
+
+	name := b.String()
+	if name == "" || unicode.IsDigit([]rune(name)[0]) {
+		name = "X" + name
+	}
+
+	return name
+}
+
+// lowerFirst unexports a Go name, like petsService from PetsService.
+func lowerFirst(s string) string {
+	r := []rune(s)
+	for i := range r {
+		// Keep going through initialisms, so IDService becomes idService
+		if i > 0 && i+1 < len(r) && !unicode.IsUpper(r[i+1]) {
+			break
+		}
+		r[i] = unicode.ToLower(r[i])
+	}
+
+	return string(r)
+}
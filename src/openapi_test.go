@@ -0,0 +1,360 @@
+package src
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_LoadAPI(t *testing.T) {
+	api, err := LoadAPI("testdata/openapi/petstore.yaml")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var types []string
+	for _, typ := range api.Types {
+		types = append(types, typ.Name+" "+typ.Alias)
+	}
+	wantTypes := []string{
+		"Pet ", "NewPet ", "Pets []Pet", "Error ",
+		"PostStoresStoreIDOrdersRequest ", "PostStoresStoreIDOrdersRequestItemsItem ", "PostStoresStoreIDOrdersResponse ",
+	}
+	if !reflect.DeepEqual(wantTypes, types) {
+		t.Errorf("expected types: %v got: %v", wantTypes, types)
+	}
+
+	if !api.UsesTime {
+		t.Error("expected placedAt to use time")
+	}
+
+	var operations []string
+	for _, s := range api.Services {
+		for _, op := range s.Operations {
+			operations = append(operations, strings.Join([]string{s.Name, s.Impl, op.Method, op.Path, op.Name, op.Body, op.Result, op.StatusCode()}, " "))
+		}
+	}
+	wantOperations := []string{
+		"PetsService petsService GET /pets ListPets  Pets http.StatusOK",
+		"PetsService petsService POST /pets CreatePet NewPet Pet http.StatusCreated",
+		"PetsService petsService GET /pets/{petId} ShowPetByID  Pet http.StatusOK",
+		"PetsService petsService DELETE /pets/{petId} DeletePet   http.StatusNoContent",
+		"StoreService storeService POST /stores/{storeId}/orders PostStoresStoreIDOrders PostStoresStoreIDOrdersRequest PostStoresStoreIDOrdersResponse http.StatusAccepted",
+	}
+	if !reflect.DeepEqual(wantOperations, operations) {
+		t.Errorf("expected operations:\n%s\ngot:\n%s", strings.Join(wantOperations, "\n"), strings.Join(operations, "\n"))
+	}
+
+	order := api.Services[1].Operations[0]
+	wantParams := []Param{
+		{Name: "StoreID", Key: "storeId", In: "path", Type: "string", Required: true},
+		{Name: "XRequestID", Key: "X-Request-Id", In: "header", Type: "string"},
+		{Name: "DryRun", Key: "dryRun", In: "query", Type: "bool", Required: true},
+	}
+	if !reflect.DeepEqual(wantParams, order.Params) {
+		t.Errorf("expected params: %+v got: %+v", wantParams, order.Params)
+	}
+
+	if want := "/stores/x/orders?dryRun=true"; order.Sample() != want {
+		t.Errorf("expected sample: %s got: %s", want, order.Sample())
+	}
+
+	// The path parameter comes from components, through the path
+	if params := api.Services[0].Operations[2].Params; len(params) != 1 || params[0].Type != "int64" {
+		t.Errorf("expected the shared petId parameter, got: %+v", params)
+	}
+}
+
+// loadAPI writes doc to a file for LoadAPI
+func loadAPI(t *testing.T, doc string) (*API, error) {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), "api.yaml")
+	if err := os.WriteFile(file, []byte(doc), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return LoadAPI(file)
+}
+
+func Test_LoadAPI_schemas(t *testing.T) {
+	testCases := []struct {
+		name    string
+		version string
+		schema  string
+		want    string
+	}{
+		{name: "additional properties allowed", version: "3.0.3", schema: "{type: object, additionalProperties: true}", want: "map[string]any"},
+		{name: "additional properties not allowed", version: "3.0.3", schema: "{type: object, additionalProperties: false}", want: "map[string]any"},
+		{name: "additional properties schema", version: "3.0.3", schema: "{type: object, additionalProperties: {type: integer}}", want: "map[string]int"},
+		{name: "additional properties without a type", version: "3.1.0", schema: "{additionalProperties: true}", want: "map[string]any"},
+		{name: "type list", version: "3.1.0", schema: "{type: [string]}", want: "string"},
+		{name: "nullable", version: "3.1.0", schema: `{type: [string, "null"]}`, want: "*string"},
+		{name: "nullable object", version: "3.1.0", schema: `{type: [object, "null"], properties: {name: {type: string}}}`, want: "*ThingValue"},
+		{name: "nullable array", version: "3.1.0", schema: `{type: [array, "null"], items: {type: integer}}`, want: "[]int"},
+		{name: "several types", version: "3.1.0", schema: "{type: [string, integer]}", want: "any"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			api, err := loadAPI(t, "openapi: "+tC.version+`
+components:
+  schemas:
+    Thing:
+      type: object
+      properties:
+        value: `+tC.schema+`
+        name:
+          type: string
+`)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got := api.Types[0].Fields[0].Type; got != tC.want {
+				t.Errorf("expected: %s got: %s", tC.want, got)
+			}
+		})
+	}
+}
+
+func Test_LoadAPI_typeNames(t *testing.T) {
+	api, err := loadAPI(t, `openapi: 3.0.3
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  pets: {type: array, items: {type: string}}
+    post:
+      operationId: createPet
+      responses:
+        "201":
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/ListPetsResponse"
+  /pets/{id}:
+    get:
+      operationId: listPetsAgain
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  next: {type: string}
+components:
+  schemas:
+    ListPetsResponse:
+      type: object
+      properties:
+        total: {type: integer}
+    ListPetsAgainResponse:
+      type: object
+      properties:
+        next: {type: string}
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	want := map[string]string{
+		"ListPets":      "ListPetsResponse2",
+		"CreatePet":     "ListPetsResponse",
+		"ListPetsAgain": "ListPetsAgainResponse",
+	}
+	for _, op := range api.Services[0].Operations {
+		if op.Result != want[op.Name] {
+			t.Errorf("%s: expected: %s got: %s", op.Name, want[op.Name], op.Result)
+		}
+	}
+
+	var names []string
+	for _, typ := range api.Types {
+		names = append(names, typ.Name)
+	}
+	if want := []string{"ListPetsResponse", "ListPetsAgainResponse", "ListPetsResponse2"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("expected: %v got: %v", want, names)
+	}
+}
+
+func Test_LoadAPI_document(t *testing.T) {
+	api, err := loadAPI(t, `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      description: Pets <b>and</b> more
+      responses:
+        200:
+          description: The pets
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if api.OpenAPI != "3.1.0" {
+		t.Errorf("expected version 3.1.0 got: %s", api.OpenAPI)
+	}
+
+	var doc struct {
+		Paths map[string]map[string]struct {
+			Description string
+			Responses   map[string]any
+		}
+	}
+	if err := json.Unmarshal([]byte(api.Document), &doc); err != nil {
+		t.Fatalf("expected the document as JSON, got %s: %s", err, api.Document)
+	}
+
+	get := doc.Paths["/pets"]["get"]
+	if get.Description != "Pets <b>and</b> more" {
+		t.Errorf("expected the description as it was, got: %s", get.Description)
+	}
+	if _, ok := get.Responses["200"]; !ok {
+		t.Errorf("expected the 200 response, got: %v", get.Responses)
+	}
+	if !strings.Contains(api.Document, "<b>") {
+		t.Errorf("expected HTML left unescaped, got: %s", api.Document)
+	}
+}
+
+func Test_LoadAPI_errors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		doc     string
+		wantErr string
+	}{
+		{
+			name:    "swagger",
+			doc:     "swagger: \"2.0\"\n",
+			wantErr: "not an OpenAPI 3 document",
+		},
+		{
+			name:    "not yaml",
+			doc:     "openapi: [3\n",
+			wantErr: "unable to parse",
+		},
+		{
+			name: "unknown schema",
+			doc: `openapi: 3.0.3
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Pet"
+`,
+			wantErr: "GET /pets: unknown schema Pet",
+		},
+		{
+			name: "external reference",
+			doc: `openapi: 3.0.3
+components:
+  schemas:
+    Pet:
+      $ref: "pets.yaml#/Pet"
+`,
+			wantErr: "schema Pet: unsupported reference pets.yaml#/Pet",
+		},
+		{
+			name: "operation names",
+			doc: `openapi: 3.0.3
+paths:
+  /things:
+    get:
+      operationId: get_thing
+      tags: [things]
+    post:
+      operationId: getThing
+      tags: [widgets]
+`,
+			wantErr: "POST /things: operations get_thing and getThing are both named GetThing",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			_, err := loadAPI(t, tC.doc)
+			if err == nil || !strings.Contains(err.Error(), tC.wantErr) {
+				t.Errorf("expected `%v` to contain `%s`", err, tC.wantErr)
+			}
+		})
+	}
+}
+
+func Test_goName(t *testing.T) {
+	testCases := []struct {
+		in   string
+		want string
+	}{
+		{in: "pet", want: "Pet"},
+		{in: "petId", want: "PetID"},
+		{in: "pet_id", want: "PetID"},
+		{in: "X-Request-Id", want: "XRequestID"},
+		{in: "get /pets/{petId}", want: "GetPetsPetID"},
+		{in: "HTMLPage", want: "HTMLPage"},
+		{in: "2fa", want: "X2fa"},
+		{in: "", want: "X"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.in, func(t *testing.T) {
+			if got := goName(tC.in); got != tC.want {
+				t.Errorf("expected: %s got: %s", tC.want, got)
+			}
+		})
+	}
+}
+
+func Test_lowerFirst(t *testing.T) {
+	testCases := []struct {
+		in   string
+		want string
+	}{
+		{in: "PetsService", want: "petsService"},
+		{in: "IDService", want: "idService"},
+		{in: "API", want: "api"},
+		{in: "X", want: "x"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.in, func(t *testing.T) {
+			if got := lowerFirst(tC.in); got != tC.want {
+				t.Errorf("expected: %s got: %s", tC.want, got)
+			}
+		})
+	}
+}
+
+func Test_API_Qualify(t *testing.T) {
+	api := &API{Types: []Type{{Name: "Pet"}, {Name: "Time"}}}
+
+	testCases := []struct {
+		in   string
+		want string
+	}{
+		{in: "Pet", want: "api.Pet"},
+		{in: "[]Pet", want: "[]api.Pet"},
+		{in: "map[string]Pet", want: "map[string]api.Pet"},
+		{in: "time.Time", want: "time.Time"},
+		{in: "int64", want: "int64"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.in, func(t *testing.T) {
+			if got := api.Qualify(tC.in); got != tC.want {
+				t.Errorf("expected: %s got: %s", tC.want, got)
+			}
+		})
+	}
+}
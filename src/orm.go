@@ -1,57 +1,38 @@
 package src
 
-import (
-	"fmt"
-	"strings"
-)
-
 type ORM struct {
-	Name     string
-	Matches  []string
+	ComponentInfo `yaml:",inline" mapstructure:",squash"`
+
 	Object   string
 	Package  string
 	DBDriver map[string]string
 	Driver   string
-	Init     string
 }
 
-func findORM(name string) (ORM, error) {
-	name = strings.ToLower(name)
-
-	if o, ok := orms[name]; ok {
-		return o, nil
-	}
-
-	for k, o := range orms {
-		o.Name = k
+func (o *ORM) Kind() string {
+	return kindORM
+}
 
-		if strings.EqualFold(name, o.Name) {
-			return o, nil
-		}
+func (o *ORM) Info() *ComponentInfo {
+	return &o.ComponentInfo
+}
 
-		for _, m := range o.Matches {
-			if strings.EqualFold(name, m) {
-				return o, nil
-			}
-		}
+func (o *ORM) goPackages() []string {
+	pkgs := []string{o.Package}
+	if o.Driver != "" {
+		pkgs = append(pkgs, o.Driver)
 	}
 
-	return ORM{}, fmt.Errorf("no ORM matching: %s", name)
+	return append(pkgs, o.Packages...)
 }
 
-var orms = map[string]ORM{
-	"gorm": {
-		Name:    "gorm",
-		Object:  "*gorm.DB",
-		Package: "gorm.io/gorm",
-		Driver:  "gorm.io/driver/",
-		DBDriver: map[string]string{
-			"mysql":     "mysql",
-			"postgres":  "postgres",
-			"sqlite":    "sqlite",
-			"sqlserver": "sqlserver",
-			"tidb":      "mysql",
-		},
-		Init: `DB, err = gorm.Open({{ .DBDriver }}.Open(app.DatabaseDsn), &gorm.Config{})`,
-	},
+// setDriver completes the driver package for the selected database,
+// or clears it if the database doesn't need one.
+func (o *ORM) setDriver(database string) {
+	if driver := o.DBDriver[database]; driver != "" {
+		o.Driver += driver
+		return
+	}
+
+	o.Driver = ""
 }
@@ -9,7 +9,7 @@ func Test_findORM(t *testing.T) {
 	testCases := []struct {
 		name    string
 		search  string
-		want    ORM
+		want    string
 		wantErr string
 	}{
 		{
@@ -20,17 +20,17 @@ func Test_findORM(t *testing.T) {
 		{
 			name:   "exact name",
 			search: "gorm",
-			want:   orms["gorm"],
+			want:   "gorm",
 		},
 		// {
 		// 	name:   "exact match",
 		// 	search: "gorm",
-		// 	want:   databases["gorm"],
+		// 	want:   "gorm",
 		// },
 		{
 			name:   "different case",
 			search: "GORM",
-			want:   orms["gorm"],
+			want:   "gorm",
 		},
 		{
 			name:    "not found",
@@ -40,15 +40,15 @@ func Test_findORM(t *testing.T) {
 	}
 	for _, tC := range testCases {
 		t.Run(tC.name, func(t *testing.T) {
-			want, err := findORM(tC.search)
+			want, err := findComponent[ORM](tC.search)
 			if tC.wantErr != "" {
 				if !strings.Contains(err.Error(), tC.wantErr) {
 					t.Errorf("expected `%s` to contain `%s`", err.Error(), tC.wantErr)
 				}
 				return
 			}
-			if tC.want.Name != want.Name {
-				t.Errorf("expected: `%s` got: `%s`", tC.want.Name, want.Name)
+			if tC.want != want.Name {
+				t.Errorf("expected: `%s` got: `%s`", tC.want, want.Name)
 			}
 		})
 	}
@@ -0,0 +1,54 @@
+package src
+
+import (
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// writer is where generated folders and files end up.
+// source is the template or file the contents came from.
+type writer interface {
+	MkdirAll(name string) error
+	WriteFile(name, source string, contents []byte, perm fs.FileMode) error
+}
+
+// diskWriter writes files relative to the working directory.
+type diskWriter struct{}
+
+func (diskWriter) MkdirAll(name string) error {
+	return makeFolder(name)
+}
+
+func (diskWriter) WriteFile(name, _ string, contents []byte, perm fs.FileMode) error {
+	log.Println("making file:", name)
+	if err := os.WriteFile(name, contents, perm); err != nil {
+		return err
+	}
+
+	return os.Chmod(name, perm)
+}
+
+// memFile is a file generated in memory.
+type memFile struct {
+	Source   string
+	Contents []byte
+	Perm     fs.FileMode
+}
+
+// memWriter keeps generated files in memory, keyed by their slash separated path.
+type memWriter map[string]memFile
+
+func (memWriter) MkdirAll(string) error {
+	return nil
+}
+
+func (m memWriter) WriteFile(name, source string, contents []byte, perm fs.FileMode) error {
+	m[filepath.ToSlash(filepath.Clean(name))] = memFile{
+		Source:   source,
+		Contents: contents,
+		Perm:     perm,
+	}
+	return nil
+}
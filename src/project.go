@@ -9,7 +9,9 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"slices"
 	"strings"
 	"text/template"
 	"time"
@@ -19,6 +21,21 @@ import (
 
 const ext = ".template"
 
+// pathParam is a parameter in an OpenAPI path, like {id}
+var pathParam = regexp.MustCompile(`\{[^/{}]+\}`)
+
+// apiVersion is a version of the API, a single segment of its path like v1
+var apiVersion = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// hooks are the named templates the built-in templates leave
+// for components to add to, like the routes of the app.
+var hooks = []string{
+	"API Example Route", "API Example Version", "API Imports", "API Route Fields", "API Route Wrappers",
+	"API Version Fields", "Action Imports", "CORS Exposed Headers", "Docker Services", "Flags", "Health Checks",
+	"Health Imports", "Middleware", "Models", "OpenAPI Paths", "Root Imports", "Routes", "Serve Imports",
+	"Shutdown", "Startup",
+}
+
 type Project struct {
 	AppName   string
 	Copyright string
@@ -33,12 +50,29 @@ type Project struct {
 	Database  Database
 	ORM       ORM
 	Router    Router
-
-	Templates map[string]string
-
+	Features  []string
+	// OpenAPI document to generate the API from, spec first
+	FromOpenAPI string
+	// Version the API is first served as, under /api/<version>
+	APIVersion string
+	Transport  Transport
+
+	ComponentDirs []string
+	Components    Components
+	Packs         []string
+	Templates     map[string]string
+	Blocks        map[string]string
+	Vars          map[string]any
+	Set           []string
+
+	api          *API
 	absolutePath string
 	templates    *template.Template
 	packages     []string
+	selected     []Component
+	env          []EnvVar
+	out          writer
+	run          func(name string, args ...string) error
 }
 
 func NewProject() Project {
@@ -52,6 +86,8 @@ func NewProject() Project {
 		},
 		Version:      getGoVersion(),
 		absolutePath: getWorkingDirectory(),
+		out:          diskWriter{},
+		run:          runCommand,
 	}
 }
 
@@ -79,13 +115,13 @@ func (p *Project) Generate() error {
 		}
 
 		log.Println("initializing go module")
-		if err := exec.Command("go", "mod", "init", p.PkgName).Run(); err != nil {
+		if err := p.run("go", "mod", "init", p.PkgName); err != nil {
 			return err
 		}
 	}
 
 	log.Println("getting packages")
-	if err := exec.Command("go", p.packages...).Run(); err != nil {
+	if err := p.run("go", p.packages...); err != nil {
 		log.Println("WARN: unable to get packages")
 		log.Printf("go %s\n", strings.Join(p.packages, " "))
 	}
@@ -102,31 +138,73 @@ func (p *Project) Generate() error {
 		return err
 	}
 
+	if err := p.runCommands(); err != nil {
+		return err
+	}
+
 	log.Println("clean up")
-	if err := exec.Command("go", "mod", "tidy").Run(); err != nil {
+	if err := p.run("go", "mod", "tidy"); err != nil {
 		return err
 	}
 	log.Println("✓ tidy")
 
-	if err := exec.Command("go", "fmt").Run(); err != nil {
+	if err := p.run("go", "fmt", "./..."); err != nil {
 		return err
 	}
 	log.Println("✓ format")
 	return nil
 }
 
+// runCommands runs the commands of the selected components, like code generators.
+func (p *Project) runCommands() error {
+	for _, c := range p.selected {
+		for _, command := range c.Info().Commands {
+			args := strings.Fields(command)
+			if len(args) == 0 {
+				continue
+			}
+
+			log.Println("running", command)
+			if err := p.run(args[0], args[1:]...); err != nil {
+				return fmt.Errorf("unable to run %s for %s: %w", command, describe(c), err)
+			}
+		}
+	}
+
+	return nil
+}
+
 func (p *Project) addNamedTemplate(name, content string) error {
 	var err error
 	p.templates, err = p.templates.Parse(fmt.Sprintf(`{{ define "%s" }}%s{{ end }}`, name, content))
 	return err
 }
 
+// copyFile copies a file byte for byte, keeping its permissions
+// while making sure it can still be overwritten by the next run.
+func (p *Project) copyFile(fSys fs.FS, path, file string) error {
+	contents, err := fs.ReadFile(fSys, path)
+	if err != nil {
+		return err
+	}
+
+	info, err := fs.Stat(fSys, path)
+	if err != nil {
+		return err
+	}
+
+	return p.out.WriteFile(file, path, contents, info.Mode().Perm()|0o600)
+}
+
 func (p *Project) data() map[string]any {
 	d := map[string]any{
+		"API":          p.api,
+		"APIVersion":   p.APIVersion,
 		"AppName":      p.AppName,
 		"Copyright":    p.Copyright,
 		"Database":     p.Database,
 		"Docker":       p.Docker,
+		"Features":     p.featureNames(),
 		"Folder":       p.Folder,
 		"Header":       p.Header,
 		"License":      p.License,
@@ -134,14 +212,67 @@ func (p *Project) data() map[string]any {
 		"PkgName":      p.PkgName,
 		"Router":       p.Router,
 		"Sentry":       p.Sentry,
+		"Transport":    p.Transport,
 		"Version":      p.Version,
-		"EnvPrefix":    p.EnvPrefix + "_",
+		"Env":          p.env,
+		"EnvPrefix":    envPrefix(p.EnvPrefix),
 		"EnvPrefixVar": p.EnvPrefix,
+		"Vars":         p.Vars,
 	}
 
 	return d
 }
 
+// execute runs an already parsed template.
+func (p *Project) execute(name string) ([]byte, error) {
+	var b bytes.Buffer
+	if err := p.templates.ExecuteTemplate(&b, name, p.data()); err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// featureNames lists the enabled features for templates.
+func (p *Project) featureNames() FeatureSet {
+	names := FeatureSet{}
+	for _, c := range p.selected {
+		if c.Kind() == kindFeature {
+			names = append(names, c.Info().Name)
+		}
+	}
+
+	return names
+}
+
+// funcs are the functions templates can call, besides the built-in ones.
+func (p *Project) funcs() template.FuncMap {
+	return template.FuncMap{
+		// handle mounts an http.Handler on the selected router, like {{ handle "/metrics" "promhttp.Handler()" }}
+		"handle": func(path, handler string) string {
+			return fmt.Sprintf(p.Router.Handle, path, handler)
+		},
+		// include renders a named template to a string, to pass on to other functions
+		"include": func(name string, data any) (string, error) {
+			var b bytes.Buffer
+			err := p.templates.ExecuteTemplate(&b, name, data)
+			return b.String(), err
+		},
+		"imports": formatImports,
+		// middleware uses net/http middleware on the selected router, like {{ middleware "cache.Middleware" }}
+		"middleware": func(middleware string) string {
+			return fmt.Sprintf(p.Router.Middleware, middleware)
+		},
+		// route routes a method and an OpenAPI style path, like /pets/{id}, to an http.HandlerFunc
+		"route": func(method, path, handler string) string {
+			path = pathParam.ReplaceAllStringFunc(path, func(param string) string {
+				return fmt.Sprintf(p.Router.Param, param[1:len(param)-1])
+			})
+			return fmt.Sprintf(p.Router.Route, method, path, handler)
+		},
+	}
+}
+
 func (p *Project) makeFiles() error {
 	log.Println("generating files from templates...")
 
@@ -150,58 +281,49 @@ func (p *Project) makeFiles() error {
 		return err
 	}
 
-	if err := fs.WalkDir(fSys, ".", func(path string, d fs.DirEntry, err error) error {
-		if err != nil {
-			return err
-		}
-
-		// change __application__ folder to user specified application folder
-		file := p.replaceAppFolder(path)
+	if err := p.makeFilesFrom(fSys, true); err != nil {
+		return err
+	}
 
-		// Make directories as needed
-		if d.IsDir() {
-			return makeFolder(file)
+	// Make files from the selected components, like the router's tests
+	for _, c := range p.selected {
+		if c.Info().files == nil {
+			continue
 		}
 
-		// remove .template extension
-		file = file[:len(file)-len(ext)]
-
-		var b bytes.Buffer
-		if err := p.templates.ExecuteTemplate(&b, filepath.Base(path), p.data()); err != nil {
-			return err
+		log.Println("generating files for", describe(c))
+		if err := p.makeFilesFrom(c.Info().files, false); err != nil {
+			return fmt.Errorf("unable to generate %s: %w", describe(c), err)
 		}
+	}
 
-		if b.Len() != 0 {
-			log.Println("making file:", file)
-			return os.WriteFile(file, b.Bytes(), 0o640)
+	// Make files from template packs, overwriting built-in files of the same name
+	for _, pack := range p.Packs {
+		log.Println("generating files from pack:", pack)
+		if err := p.makeFilesFrom(os.DirFS(pack), false); err != nil {
+			return fmt.Errorf("unable to generate pack %s: %w", pack, err)
 		}
-		return nil
-	}); err != nil {
-		return err
 	}
 
 	// Make provided templates
 	if len(p.Templates) > 0 {
-		var err error
 		log.Println("generating user supplied templates...")
 		for k, contents := range p.Templates {
-			if contents != "" {
-				p.templates, err = p.templates.Parse(contents)
-				if err != nil {
-					return err
-				}
+			if contents == "" {
+				continue
+			}
 
-				if err := makeFolder(filepath.Dir(k)); err != nil {
-					return nil
-				}
+			if err := p.out.MkdirAll(filepath.Dir(k)); err != nil {
+				return err
+			}
 
-				var b bytes.Buffer
-				if err := p.templates.Execute(&b, p.data()); err != nil {
-					return err
-				}
+			b, err := p.render(k, contents)
+			if err != nil {
+				return err
+			}
 
-				log.Println("making file:", k)
-				return os.WriteFile(k, b.Bytes(), 0o640)
+			if err := p.out.WriteFile(k, k, b, 0o640); err != nil {
+				return err
 			}
 		}
 	}
@@ -209,6 +331,54 @@ func (p *Project) makeFiles() error {
 	return nil
 }
 
+// makeFilesFrom walks a folder laid out like templates/files. Files ending in
+// .template are rendered, anything else is copied as is, keeping its permissions.
+// Built-in templates are already parsed, and are looked up by their file name
+// so the license templates can take the place of their placeholders.
+// Templates rendering nothing are skipped, and folders are only made for files.
+func (p *Project) makeFilesFrom(fSys fs.FS, builtin bool) error {
+	return fs.WalkDir(fSys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		// change __application__ folder to user specified application folder
+		file := p.replaceAppFolder(path)
+
+		if !strings.HasSuffix(file, ext) {
+			if err := p.out.MkdirAll(filepath.Dir(file)); err != nil {
+				return err
+			}
+			return p.copyFile(fSys, path, file)
+		}
+
+		// remove .template extension
+		file = file[:len(file)-len(ext)]
+
+		var b []byte
+		if builtin {
+			b, err = p.execute(filepath.Base(path))
+		} else {
+			var contents []byte
+			if contents, err = fs.ReadFile(fSys, path); err == nil {
+				b, err = p.render(path, string(contents))
+			}
+		}
+		if err != nil {
+			return err
+		}
+
+		if len(b) == 0 {
+			return nil
+		}
+
+		if err := p.out.MkdirAll(filepath.Dir(file)); err != nil {
+			return err
+		}
+		return p.out.WriteFile(file, path, b, 0o640)
+	})
+}
+
 func (p *Project) parseGoMod() (bool, error) {
 	log.Println("checking go.mod...")
 	needInit := true
@@ -258,9 +428,22 @@ func (p *Project) parseGoMod() (bool, error) {
 	})
 }
 
+// render parses contents as a template named name, with access to
+// all of the named templates and blocks, and runs it.
+func (p *Project) render(name, contents string) ([]byte, error) {
+	if _, err := p.templates.New(name).Parse(contents); err != nil {
+		return nil, err
+	}
+
+	return p.execute(name)
+}
+
 func (p *Project) replaceAppFolder(s string) string {
 	app := "__application__"
 	if p.Folder == "" {
+		if s == app {
+			return "."
+		}
 		app += "/"
 	}
 
@@ -276,27 +459,46 @@ func (p *Project) setup() error {
 		return err
 	}
 
+	if p.APIVersion == "" {
+		p.APIVersion = "v1"
+	}
+	if !apiVersion.MatchString(p.APIVersion) || p.APIVersion == "." || p.APIVersion == ".." {
+		return fmt.Errorf("invalid api version, expected a single path segment like v1: %s", p.APIVersion)
+	}
+
+	if err := p.setTransport(); err != nil {
+		return err
+	}
+
 	var err error
-	p.templates, err = template.ParseFS(
+	p.templates, err = template.New("").Funcs(p.funcs()).ParseFS(
 		templates.FS,
 		"files/*"+ext,
 		"files/*/*"+ext,
 		"files/*/*/*"+ext,
+		"files/*/*/*/*/*"+ext,
 		"licenses/"+p.License+"/*"+ext,
 	)
 	if err != nil {
 		return err
 	}
 
-	if err := p.setDatabase(); err != nil {
+	// Hooks default to empty, for components to fill in with their blocks
+	for _, name := range hooks {
+		if err := p.addNamedTemplate(name, ""); err != nil {
+			return err
+		}
+	}
+
+	if err := p.setComponents(); err != nil {
 		return err
 	}
 
-	if err := p.setORM(); err != nil {
+	if err := p.setBlocks(); err != nil {
 		return err
 	}
 
-	if err := p.setRouter(); err != nil {
+	if err := p.setVars(); err != nil {
 		return err
 	}
 
@@ -319,14 +521,80 @@ func (p *Project) setup() error {
 	return nil
 }
 
-func (p *Project) setDatabase() error {
+// setBlocks redefines named templates with the user supplied blocks.
+// Config keys are lowercased, so names are matched against the existing
+// templates without case to find the one being replaced.
+func (p *Project) setBlocks() error {
+	for name, content := range p.Blocks {
+		name = p.templateName(name)
+		if err := p.addNamedTemplate(name, content); err != nil {
+			return fmt.Errorf("unable to parse block %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// setComponents finds the selected components and features and resolves them together.
+func (p *Project) setComponents() error {
 	var err error
-	p.Database, err = findDatabase(p.Database.Name)
-	if err != nil {
+	if p.Database, err = findComponent[Database](p.Database.Name); err != nil {
 		return err
 	}
 
-	return p.addNamedTemplate("Docker DB Env", p.Database.DockerEnv)
+	if p.ORM, err = findComponent[ORM](p.ORM.Name); err != nil {
+		return err
+	}
+
+	p.ORM.setDriver(p.Database.Name)
+
+	selected := []Component{&p.Database, &p.ORM}
+
+	// Apps only served over gRPC have no router
+	if p.Transport.HTTP() {
+		if p.Router, err = findComponent[Router](p.Router.Name); err != nil {
+			return err
+		}
+
+		// The router's app is a template, so features can add their routes to it
+		if err := p.addNamedTemplate("Router App", p.Router.App); err != nil {
+			return fmt.Errorf("unable to parse router %s: %w", p.Router.Name, err)
+		}
+
+		selected = append(selected, &p.Router)
+	} else {
+		p.Router = Router{}
+	}
+
+	for _, name := range p.Features {
+		f, err := findComponent[Feature](name)
+		if err != nil {
+			return err
+		}
+
+		if !slices.ContainsFunc(selected, refersTo(kindFeature, f.Name)) {
+			selected = append(selected, &f)
+		}
+	}
+
+	// The API designed up front is generated by the openapi spec component
+	if p.FromOpenAPI != "" {
+		if p.api, err = LoadAPI(p.FromOpenAPI); err != nil {
+			return err
+		}
+
+		s, err := findComponent[Spec]("openapi")
+		if err != nil {
+			return err
+		}
+		selected = append(selected, &s)
+	}
+
+	if err := p.resolve(selected...); err != nil {
+		return err
+	}
+
+	return p.checkTransport()
 }
 
 func (p *Project) setLicense() error {
@@ -335,31 +603,33 @@ func (p *Project) setLicense() error {
 	return err
 }
 
-func (p *Project) setORM() error {
-	var err error
-	p.ORM, err = findORM(p.ORM.Name)
-	if err != nil {
-		return err
+func (p *Project) setVars() error {
+	if p.Vars == nil {
+		p.Vars = map[string]any{}
 	}
 
-	p.packages = append(p.packages, p.ORM.Package)
-	if driver := p.ORM.DBDriver[p.Database.Name]; driver != "" {
-		p.ORM.Driver += p.ORM.DBDriver[p.Database.Name]
-		p.packages = append(p.packages, p.ORM.Driver)
+	for _, s := range p.Set {
+		k, v, err := parseVar(s)
+		if err != nil {
+			return err
+		}
+
+		p.Vars[k] = v
 	}
 
-	return p.addNamedTemplate("ORM Init", p.ORM.Init)
+	return nil
 }
 
-func (p *Project) setRouter() error {
-	var err error
-	p.Router, err = findRouter(p.Router.Name)
-	if err != nil {
-		return err
+// templateName is the name of the existing template matching name without case,
+// as config keys are lowercased, or name itself if there is none.
+func (p *Project) templateName(name string) string {
+	for _, t := range p.templates.Templates() {
+		if strings.EqualFold(name, t.Name()) {
+			return t.Name()
+		}
 	}
 
-	p.packages = append(p.packages, p.Router.Package)
-	return nil
+	return name
 }
 
 func changeFolder(name string) error {
@@ -382,6 +652,16 @@ func changeFolder(name string) error {
 	return os.Chdir(name)
 }
 
+// envPrefix is how environment variable names start for prefix, which
+// viper uppercases and separates from the rest of the name with an underscore.
+func envPrefix(prefix string) string {
+	if prefix == "" {
+		return ""
+	}
+
+	return strings.ToUpper(prefix) + "_"
+}
+
 func getGoVersion() string {
 	v := runtime.Version()
 	if !strings.HasPrefix(v, "go") {
@@ -409,11 +689,15 @@ func makeFolder(name string) error {
 		return nil
 	}
 
-	err := os.MkdirAll(name, 0o0755)
-	if os.IsExist(err) {
+	if _, err := os.Stat(name); err == nil {
 		return nil
 	}
 
 	log.Println("making folder:", name)
-	return err
+	return os.MkdirAll(name, 0o0755)
+}
+
+// runCommand runs a command, such as the go toolchain, in the working directory.
+func runCommand(name string, args ...string) error {
+	return exec.Command(name, args...).Run()
 }
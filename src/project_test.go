@@ -0,0 +1,228 @@
+package src
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"text/template"
+
+	"github.com/spf13/viper"
+)
+
+func Test_setBlocks(t *testing.T) {
+	testCases := []struct {
+		name    string
+		blocks  map[string]string
+		execute string
+		want    string
+		wantErr string
+	}{
+		{
+			name:    "no blocks",
+			execute: "ORM Init",
+			want:    "built-in",
+		},
+		{
+			name:    "exact name",
+			blocks:  map[string]string{"ORM Init": "custom"},
+			execute: "ORM Init",
+			want:    "custom",
+		},
+		{
+			name:    "lowercased name",
+			blocks:  map[string]string{"orm init": "custom {{ .AppName }}"},
+			execute: "ORM Init",
+			want:    "custom app",
+		},
+		{
+			name:    "new block",
+			blocks:  map[string]string{"footer": "custom"},
+			execute: "footer",
+			want:    "custom",
+		},
+		{
+			name:    "invalid",
+			blocks:  map[string]string{"orm init": "{{ .AppName "},
+			wantErr: "unable to parse block ORM Init",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			p := Project{Blocks: tC.blocks}
+			p.templates = template.Must(template.New("").Parse(`{{ define "ORM Init" }}built-in{{ end }}`))
+
+			err := p.setBlocks()
+			if tC.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tC.wantErr) {
+					t.Errorf("expected `%v` to contain `%s`", err, tC.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			var b bytes.Buffer
+			if err := p.templates.ExecuteTemplate(&b, tC.execute, map[string]any{"AppName": "app"}); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if tC.want != b.String() {
+				t.Errorf("expected: `%s` got: `%s`", tC.want, b.String())
+			}
+		})
+	}
+}
+
+func Test_runCommands(t *testing.T) {
+	testCases := []struct {
+		name     string
+		commands []string
+		fail     string
+		want     []string
+		wantErr  string
+	}{
+		{
+			name: "no commands",
+		},
+		{
+			name:     "commands",
+			commands: []string{"go run example.com/gen generate", "  ", "make stubs"},
+			want:     []string{"go run example.com/gen generate", "make stubs"},
+		},
+		{
+			name:     "failing command",
+			commands: []string{"make stubs", "go run example.com/gen generate"},
+			fail:     "make",
+			want:     []string{"make stubs"},
+			wantErr:  "unable to run make stubs for feature gen: failed",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			var ran []string
+			p := Project{
+				selected: []Component{&Feature{ComponentInfo{Name: "gen", Commands: tC.commands}}},
+				run: func(name string, args ...string) error {
+					ran = append(ran, strings.Join(append([]string{name}, args...), " "))
+					if name == tC.fail {
+						return errors.New("failed")
+					}
+					return nil
+				},
+			}
+
+			err := p.runCommands()
+			if tC.wantErr != "" {
+				if err == nil || err.Error() != tC.wantErr {
+					t.Errorf("expected error `%s` got: `%v`", tC.wantErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !slices.Equal(tC.want, ran) {
+				t.Errorf("expected: %v got: %v", tC.want, ran)
+			}
+		})
+	}
+}
+
+func Test_envPrefix(t *testing.T) {
+	testCases := []struct {
+		prefix string
+		want   string
+	}{
+		{prefix: "", want: ""},
+		{prefix: "echo", want: "ECHO_"},
+		{prefix: "SECURE", want: "SECURE_"},
+		{prefix: "my_app", want: "MY_APP_"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.prefix, func(t *testing.T) {
+			got := envPrefix(tC.prefix)
+			if tC.want != got {
+				t.Errorf("expected: `%s` got: `%s`", tC.want, got)
+			}
+
+			// The generated app reads the variables with viper, set up like cmd/root.go does
+			v := viper.New()
+			v.SetEnvPrefix(tC.prefix)
+			v.AutomaticEnv()
+			t.Setenv(got+"APP_NAME", "app")
+			if name := v.GetString("app_name"); name != "app" {
+				t.Errorf("expected viper to read %sAPP_NAME, got: `%s`", got, name)
+			}
+		})
+	}
+}
+
+func Test_makeFilesFrom(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\xff\x00")
+	pack := fstest.MapFS{
+		"__application__/scripts/run.sh": {Data: []byte("#!/bin/sh\n# {{ not a template }}\r\nexec go run . \"$@\"\n"), Mode: 0o755},
+		"static/logo.png":                {Data: png, Mode: 0o644},
+		"static/read-only.txt":           {Data: []byte("{{ .AppName }}"), Mode: 0o444},
+		"docs/TEAM.md.template":          {Data: []byte("# {{ .AppName }}\n"), Mode: 0o755},
+	}
+
+	testCases := []struct {
+		name     string
+		file     string
+		want     []byte
+		wantPerm fs.FileMode
+	}{
+		{
+			name:     "script",
+			file:     "service/scripts/run.sh",
+			want:     pack["__application__/scripts/run.sh"].Data,
+			wantPerm: 0o755,
+		},
+		{
+			name:     "binary",
+			file:     "static/logo.png",
+			want:     png,
+			wantPerm: 0o644,
+		},
+		{
+			name:     "braces left as they are, and writable for the next run",
+			file:     "static/read-only.txt",
+			want:     []byte("{{ .AppName }}"),
+			wantPerm: 0o644,
+		},
+		{
+			name:     "template",
+			file:     "docs/TEAM.md",
+			want:     []byte("# app\n"),
+			wantPerm: 0o640,
+		},
+	}
+
+	files := memWriter{}
+	p := Project{AppName: "app", Folder: "service", out: files}
+	p.templates = template.New("")
+	if err := p.makeFilesFrom(pack, false); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(files) != len(testCases) {
+		t.Errorf("expected %d files got: %d", len(testCases), len(files))
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			f, ok := files[tC.file]
+			if !ok {
+				t.Fatalf("expected %s to be made", tC.file)
+			}
+			if !bytes.Equal(tC.want, f.Contents) {
+				t.Errorf("expected: %q got: %q", tC.want, f.Contents)
+			}
+			if tC.wantPerm != f.Perm {
+				t.Errorf("expected permissions: %s got: %s", tC.wantPerm, f.Perm)
+			}
+		})
+	}
+}
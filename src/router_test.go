@@ -9,7 +9,7 @@ func Test_findRouter(t *testing.T) {
 	testCases := []struct {
 		name    string
 		search  string
-		want    Router
+		want    string
 		wantErr string
 	}{
 		{
@@ -20,12 +20,17 @@ func Test_findRouter(t *testing.T) {
 		{
 			name:   "exact name",
 			search: "gin",
-			want:   routers["gin"],
+			want:   "gin",
 		},
 		{
 			name:   "different case",
 			search: "ECHO",
-			want:   routers["echo"],
+			want:   "echo",
+		},
+		{
+			name:   "standard library",
+			search: "stdlib",
+			want:   "http",
 		},
 		{
 			name:    "not found",
@@ -35,15 +40,15 @@ func Test_findRouter(t *testing.T) {
 	}
 	for _, tC := range testCases {
 		t.Run(tC.name, func(t *testing.T) {
-			want, err := findRouter(tC.search)
+			want, err := findComponent[Router](tC.search)
 			if tC.wantErr != "" {
 				if !strings.Contains(err.Error(), tC.wantErr) {
 					t.Errorf("expected `%s` to contain `%s`", err.Error(), tC.wantErr)
 				}
 				return
 			}
-			if tC.want.Name != want.Name {
-				t.Errorf("expected: `%s` got: `%s`", tC.want.Name, want.Name)
+			if tC.want != want.Name {
+				t.Errorf("expected: `%s` got: `%s`", tC.want, want.Name)
 			}
 		})
 	}
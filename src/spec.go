@@ -0,0 +1,19 @@
+package src
+
+// Spec generates the code for an API designed up front, from the API read
+// from an OpenAPI document, which its templates have as .API.
+type Spec struct {
+	ComponentInfo `yaml:",inline" mapstructure:",squash"`
+}
+
+func (s *Spec) Kind() string {
+	return kindSpec
+}
+
+func (s *Spec) Info() *ComponentInfo {
+	return &s.ComponentInfo
+}
+
+func (s *Spec) goPackages() []string {
+	return s.Packages
+}
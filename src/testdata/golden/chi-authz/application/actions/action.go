@@ -0,0 +1,54 @@
+package actions
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"example.com/newsroom/api"
+	"example.com/newsroom/auth"
+	"example.com/newsroom/health"
+	"example.com/newsroom/middleware"
+	"github.com/go-chi/chi/v5"
+)
+
+// Init initializes the actions package
+func Init() {
+}
+
+// App Creates and returns the application with routes
+func App() *chi.Mux {
+	app := chi.NewRouter()
+
+	app.Use(
+		middleware.RequestID,
+		middleware.RealIP,
+		middleware.AccessLog,
+		middleware.Recover,
+		middleware.CORS,
+		middleware.BodyLimit,
+		middleware.Timeout,
+	)
+
+	app.Get("/", homeView)
+	app.Handle("/healthz", health.Liveness())
+	app.Handle("/readyz", health.Readiness())
+	app.Handle("/version", health.Version())
+	api.Register(app)
+	app.Handle("/me", auth.Required(auth.Me))
+
+	return app
+}
+
+// writeJSON writes body as the JSON response with status
+func writeJSON(w http.ResponseWriter, status int, body any) error {
+	contents, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_, err = w.Write(contents)
+	return err
+}
@@ -0,0 +1,81 @@
+package actions
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"example.com/newsroom/api"
+)
+
+func (as *ActionSuite) Test_api() {
+	testCases := []struct {
+		name       string
+		path       string
+		wantStatus int
+		want       string
+	}{
+		{
+			name:       "list widgets",
+			path:       "/api/v1/widgets",
+			wantStatus: http.StatusOK,
+			want:       `[{"id":1,"name":"Sprocket"},{"id":2,"name":"Gear"}]`,
+		},
+		{
+			name:       "get widget",
+			path:       "/api/v1/widgets/2",
+			wantStatus: http.StatusOK,
+			want:       `{"id":2,"name":"Gear"}`,
+		},
+		{
+			name:       "widget not found",
+			path:       "/api/v1/widgets/3",
+			wantStatus: http.StatusNotFound,
+			want:       `{"message":"widget not found"}`,
+		},
+		{
+			name:       "invalid id",
+			path:       "/api/v1/widgets/gear",
+			wantStatus: http.StatusBadRequest,
+			want:       `{"message":"id must be a number"}`,
+		},
+	}
+	for _, tC := range testCases {
+		as.Run(tC.name, func() {
+			req, _ := http.NewRequest(http.MethodGet, tC.path, nil)
+			res := as.serve(req)
+
+			as.Equal(tC.wantStatus, res.StatusCode)
+
+			var body json.RawMessage
+			as.NoError(json.NewDecoder(res.Body).Decode(&body))
+			as.JSONEq(tC.want, string(body))
+		})
+	}
+}
+
+func (as *ActionSuite) Test_api_deprecated() {
+	defer func(versions []api.Version) {
+		api.Versions = versions
+		as.router = App()
+	}(api.Versions)
+
+	deprecated := api.Versions[0]
+	deprecated.Name = "legacy"
+	deprecated.Deprecated = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	deprecated.Sunset = time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	api.Versions = append(api.Versions, deprecated)
+	as.router = App()
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/legacy/widgets", nil)
+	res := as.serve(req)
+
+	as.Equal(http.StatusOK, res.StatusCode)
+	as.Equal("@1704067200", res.Header.Get("Deprecation"))
+	as.Equal("Wed, 01 Jan 2025 00:00:00 GMT", res.Header.Get("Sunset"))
+
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/widgets", nil)
+	res = as.serve(req)
+
+	as.Empty(res.Header.Get("Deprecation"))
+}
@@ -0,0 +1,13 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/awslabs/aws-lambda-go-api-proxy/chi"
+)
+
+// Lambda handles API Gateway proxy events with the app, for running as an AWS Lambda
+func Lambda() func(context.Context, events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return chiadapter.New(App()).ProxyWithContext
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"example.com/newsroom/authz"
+)
+
+// Version is a version of the API, served under /api/<Name> by Register
+type Version struct {
+	Name   string
+	Routes []Route
+
+	// When the version was deprecated, and when it will be removed, sent
+	// as the Deprecation and Sunset headers so clients can move on in time
+	Deprecated time.Time
+	Sunset     time.Time
+}
+
+// Route is a method and path of a version, like GET /widgets/{id},
+// the handler reads the path parameters with r.PathValue
+type Route struct {
+	Method  string
+	Path    string
+	Handler http.HandlerFunc
+	// Permission is what the policy has to allow to call the route, every route needs one
+	Permission string
+}
+
+// Versions are the versions of the API. Add a version by copying the latest
+// with a new name, then deprecate the old one by setting Deprecated and Sunset
+var Versions = []Version{
+	{
+		Name: "v1",
+		Routes: []Route{
+			{Method: http.MethodGet, Path: "/widgets", Handler: listWidgets, Permission: "widgets:read"},
+			{Method: http.MethodGet, Path: "/widgets/{id}", Handler: getWidget, Permission: "widgets:read"},
+		},
+	},
+}
+
+// Prefix is where v is served
+func (v Version) Prefix() string {
+	return "/api/" + v.Name
+}
+
+// handler wraps the route's handler in the checks features add, like
+// authorization, and in one telling clients when v is deprecated
+func (v Version) handler(route Route) http.HandlerFunc {
+	h := route.Handler
+	h = authz.RequirePermission(route.Permission)(h)
+
+	if v.Deprecated.IsZero() && v.Sunset.IsZero() {
+		return h
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !v.Deprecated.IsZero() {
+			w.Header().Set("Deprecation", "@"+strconv.FormatInt(v.Deprecated.Unix(), 10))
+		}
+		if !v.Sunset.IsZero() {
+			w.Header().Set("Sunset", v.Sunset.UTC().Format(http.TimeFormat))
+		}
+		h(w, r)
+	}
+}
+
+// writeJSON writes body as the JSON response with status
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
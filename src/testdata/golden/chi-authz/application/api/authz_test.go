@@ -0,0 +1,21 @@
+package api
+
+import (
+	"testing"
+
+	"example.com/newsroom/authz"
+	"github.com/stretchr/testify/require"
+)
+
+// Test_permissions makes sure every route says what it needs permission to do,
+// and that the policy grants it to some role, to catch typos
+func Test_permissions(t *testing.T) {
+	for _, v := range Versions {
+		for _, route := range v.Routes {
+			t.Run(route.Method+" "+v.Prefix()+route.Path, func(t *testing.T) {
+				require.NotEmpty(t, route.Permission, "every route needs a permission")
+				require.True(t, authz.Default.Grants(route.Permission), "no role is granted %s", route.Permission)
+			})
+		}
+	}
+}
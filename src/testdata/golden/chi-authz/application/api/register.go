@@ -0,0 +1,14 @@
+package api
+
+import "github.com/go-chi/chi/v5"
+
+// Register adds the routes of every version of the API to app, in a group for each
+func Register(app chi.Router) {
+	for _, v := range Versions {
+		app.Route(v.Prefix(), func(r chi.Router) {
+			for _, route := range v.Routes {
+				r.Method(route.Method, route.Path, v.handler(route))
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// Widget is an example resource, replace it with your own
+type Widget struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+var widgets = []Widget{
+	{ID: 1, Name: "Sprocket"},
+	{ID: 2, Name: "Gear"},
+}
+
+func listWidgets(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, widgets)
+}
+
+func getWidget(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": "id must be a number"})
+		return
+	}
+
+	for _, widget := range widgets {
+		if widget.ID == id {
+			writeJSON(w, http.StatusOK, widget)
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusNotFound, map[string]string{"message": "widget not found"})
+}
@@ -0,0 +1,29 @@
+package app
+
+import "time"
+
+// Init initializes the app package
+func Init() {
+}
+
+// Set any global constants here
+const ()
+
+// Set global variables here, if needed add them to cmd/root.go
+// as a persistent flag to load them from args/config/env
+var (
+	AppName     string
+	DatabaseDsn string
+
+	// Version of the app, set when building with
+	// -ldflags "-X example.com/newsroom/app.Version=v1.2.3"
+	Version string
+
+	// Where the server listens, and its timeouts
+	Host            string
+	Port            string
+	ReadTimeout     time.Duration
+	WriteTimeout    time.Duration
+	IdleTimeout     time.Duration
+	ShutdownTimeout time.Duration
+)
@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// jwk is a JSON Web Key, with the fields of RSA public keys
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// rsaKeys are the RSA public keys of a JSON Web Key Set, by their kid
+type rsaKeys map[string]*rsa.PublicKey
+
+// loadJWKS reads the RSA signing keys of the JSON Web Key Set at path
+func loadJWKS(path string) (rsaKeys, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the JWKS file: %w", err)
+	}
+
+	return parseJWKS(b)
+}
+
+// parseJWKS reads the RSA signing keys of a JSON Web Key Set, ignoring any others
+func parseJWKS(b []byte) (rsaKeys, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(b, &set); err != nil {
+		return nil, fmt.Errorf("unable to parse the JWKS: %w", err)
+	}
+
+	keys := rsaKeys{}
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse the modulus of key %q: %w", k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse the exponent of key %q: %w", k.Kid, err)
+		}
+
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("the JWKS has no RSA signing keys")
+	}
+
+	return keys, nil
+}
+
+// keyFunc picks the key a token was signed with by its kid, or the only key when it has none
+func (k rsaKeys) keyFunc(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+	if key, ok := k[kid]; ok {
+		return key, nil
+	}
+
+	if kid == "" && len(k) == 1 {
+		for _, key := range k {
+			return key, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no key with kid %q", kid)
+}
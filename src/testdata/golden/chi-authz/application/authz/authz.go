@@ -0,0 +1,126 @@
+// Package authz decides what each principal is allowed to do, by the permissions
+// their roles are granted in the policy.
+package authz
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"net/http"
+)
+
+// Roles given by the policy to everyone, whether they're authenticated or not
+const (
+	// Anonymous is the role of requests without a principal
+	Anonymous = "anonymous"
+	// Authenticated is a role of every principal, along with their own
+	Authenticated = "authenticated"
+)
+
+// Errors of Check, for requests that aren't allowed
+var (
+	ErrUnauthenticated = errors.New("authentication required")
+	ErrForbidden       = errors.New("permission denied")
+)
+
+// PolicyFile is a YAML policy used instead of policy.yaml, set by the flag in cmd/root.go
+var PolicyFile string
+
+// policy is the policy built into the app, change policy.yaml to change it
+//
+//go:embed policy.yaml
+var policy []byte
+
+// Default is the policy Check follows, policy.yaml unless Init loads PolicyFile,
+// or it's set in Go
+var Default = mustParse(policy)
+
+// Principal is who made a request, and the roles they have
+type Principal struct {
+	Subject string   `json:"sub"`
+	Roles   []string `json:"roles"`
+}
+
+// Authenticate returns the principal making r, or nil for anonymous requests,
+// and an error when their credentials are invalid. It uses the app's
+// authentication, set it to use any other.
+var Authenticate = authenticate
+
+// Init loads the policy from PolicyFile, when it's set
+func Init() error {
+	if PolicyFile == "" {
+		return nil
+	}
+
+	p, err := LoadPolicy(PolicyFile)
+	if err != nil {
+		return err
+	}
+
+	Default = p
+	return nil
+}
+
+// mustParse parses the policy built into the app, which the tests make sure is valid
+func mustParse(b []byte) *Policy {
+	p, err := ParsePolicy(b)
+	if err != nil {
+		panic("authz: policy.yaml: " + err.Error())
+	}
+
+	return p
+}
+
+// Check returns nil when the principal making r is allowed permission, with Default.
+// Otherwise, it's ErrUnauthenticated when signing in could change that, or ErrForbidden.
+func Check(r *http.Request, permission string) error {
+	_, err := check(r, permission)
+	return err
+}
+
+// Can reports whether the principal making r is allowed permission, with Default
+func Can(r *http.Request, permission string) bool {
+	return Check(r, permission) == nil
+}
+
+// check returns the principal making r, nil when anonymous, when they're allowed permission
+func check(r *http.Request, permission string) (*Principal, error) {
+	if err := checkPermission(permission); err != nil {
+		return nil, err
+	}
+
+	principal, ok := FromContext(r.Context())
+	if !ok {
+		var err error
+		if principal, err = Authenticate(r); err != nil {
+			return nil, ErrUnauthenticated
+		}
+	}
+
+	if principal == nil {
+		if !Default.Allows([]string{Anonymous}, permission) {
+			return nil, ErrUnauthenticated
+		}
+
+		return nil, nil
+	}
+
+	if !Default.Allows(append([]string{Authenticated}, principal.Roles...), permission) {
+		return nil, ErrForbidden
+	}
+
+	return principal, nil
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx with p, for FromContext
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext returns the principal RequirePermission found making the request
+func FromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok && p != nil
+}
@@ -0,0 +1,198 @@
+package authz
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"example.com/newsroom/auth"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+// useAuthenticate makes the test's requests come from principal, or fail with err
+func useAuthenticate(t *testing.T, principal *Principal, err error) {
+	t.Helper()
+
+	prev := Authenticate
+	t.Cleanup(func() {
+		Authenticate = prev
+	})
+
+	Authenticate = func(*http.Request) (*Principal, error) {
+		return principal, err
+	}
+}
+
+func Test_ParsePolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  string
+		wantErr string
+	}{
+		{name: "valid", policy: "roles:\n  viewer:\n    permissions: [widgets:read]\n  editor:\n    inherits: [viewer]\n    permissions: [widgets:*]\n"},
+		{name: "no roles", policy: "roles: {}\n", wantErr: "no roles"},
+		{name: "not yaml", policy: "roles: [", wantErr: "unable to parse"},
+		{name: "no action", policy: "roles:\n  viewer:\n    permissions: [widgets]\n", wantErr: "invalid permission"},
+		{name: "no resource", policy: "roles:\n  viewer:\n    permissions: [\":read\"]\n", wantErr: "invalid permission"},
+		{name: "unknown role", policy: "roles:\n  editor:\n    inherits: [viewer]\n", wantErr: "unknown role viewer"},
+		{name: "cycle", policy: "roles:\n  a:\n    inherits: [b]\n  b:\n    inherits: [a]\n", wantErr: "inherits itself"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParsePolicy([]byte(tt.policy))
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_Policy_Allows(t *testing.T) {
+	p, err := ParsePolicy([]byte(`
+roles:
+  viewer:
+    permissions: [widgets:read, articles:read]
+  editor:
+    inherits: [viewer]
+    permissions: [articles:*]
+  admin:
+    permissions: ["*"]
+`))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		roles      []string
+		permission string
+		want       bool
+	}{
+		{name: "granted", roles: []string{"viewer"}, permission: "widgets:read", want: true},
+		{name: "not granted", roles: []string{"viewer"}, permission: "widgets:write"},
+		{name: "inherited", roles: []string{"editor"}, permission: "widgets:read", want: true},
+		{name: "any action", roles: []string{"editor"}, permission: "articles:delete", want: true},
+		{name: "any action of another resource", roles: []string{"editor"}, permission: "articlesx:delete"},
+		{name: "everything", roles: []string{"admin"}, permission: "billing:refund", want: true},
+		{name: "any role", roles: []string{"unknown", "viewer"}, permission: "articles:read", want: true},
+		{name: "unknown role", roles: []string{"unknown"}, permission: "widgets:read"},
+		{name: "no roles", permission: "widgets:read"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, p.Allows(tt.roles, tt.permission))
+		})
+	}
+
+	require.True(t, p.Grants("articles:publish"))
+	require.Equal(t, []string{"articles:*", "articles:read", "widgets:read"}, p.Permissions("editor"))
+}
+
+func Test_Policy_Grants(t *testing.T) {
+	p, err := ParsePolicy([]byte("roles:\n  viewer:\n    permissions: [widgets:read]\n"))
+	require.NoError(t, err)
+
+	require.True(t, p.Grants("widgets:read"))
+	require.False(t, p.Grants("widgets:write"))
+	require.False(t, p.Grants("widget:read"))
+}
+
+func Test_Init(t *testing.T) {
+	defer func(file string, p *Policy) {
+		PolicyFile, Default = file, p
+	}(PolicyFile, Default)
+
+	PolicyFile = filepath.Join(t.TempDir(), "policy.yaml")
+	require.Error(t, Init())
+
+	require.NoError(t, os.WriteFile(PolicyFile, []byte("roles:\n  anonymous:\n    permissions: [widgets:write]\n"), 0o600))
+	require.NoError(t, Init())
+	require.True(t, Default.Allows([]string{Anonymous}, "widgets:write"))
+}
+
+func Test_RequirePermission(t *testing.T) {
+	tests := []struct {
+		name       string
+		principal  *Principal
+		authErr    error
+		permission string
+		want       int
+	}{
+		{name: "anonymous allowed", permission: "widgets:read", want: http.StatusOK},
+		{name: "anonymous denied", permission: "widgets:write", want: http.StatusUnauthorized},
+		{name: "authenticated", principal: &Principal{Subject: "user-1"}, permission: "widgets:read", want: http.StatusOK},
+		{name: "forbidden", principal: &Principal{Subject: "user-1"}, permission: "widgets:write", want: http.StatusForbidden},
+		{name: "role", principal: &Principal{Subject: "user-1", Roles: []string{"editor"}}, permission: "widgets:write", want: http.StatusOK},
+		{name: "admin", principal: &Principal{Subject: "user-1", Roles: []string{"admin"}}, permission: "users:delete", want: http.StatusOK},
+		{name: "invalid credentials", authErr: errors.New("invalid token"), permission: "widgets:read", want: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useAuthenticate(t, tt.principal, tt.authErr)
+
+			var got *Principal
+			handler := RequirePermission(tt.permission)(func(w http.ResponseWriter, r *http.Request) {
+				got, _ = FromContext(r.Context())
+			})
+
+			w := httptest.NewRecorder()
+			handler(w, httptest.NewRequest(http.MethodGet, "/", nil))
+			require.Equal(t, tt.want, w.Code)
+			if tt.want == http.StatusOK {
+				require.Equal(t, tt.principal, got)
+			}
+		})
+	}
+
+	require.Panics(t, func() { RequirePermission("") })
+	require.Panics(t, func() { RequirePermission("widgets") })
+}
+
+func Test_Check(t *testing.T) {
+	useAuthenticate(t, &Principal{Subject: "user-1", Roles: []string{"editor"}}, nil)
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	require.NoError(t, Check(r, "widgets:write"))
+	require.ErrorIs(t, Check(r, "users:delete"), ErrForbidden)
+	require.True(t, Can(r, "widgets:read"))
+	require.False(t, Can(r, "users:delete"))
+}
+
+func Test_authenticate_auth(t *testing.T) {
+	defer func(secret string) {
+		auth.Secret = secret
+		auth.Init()
+	}(auth.Secret)
+
+	auth.Secret = "a-secret-that-is-long-enough-for-hs256"
+	require.NoError(t, auth.Init())
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "user-1",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+		Roles: []string{"editor"},
+	}).SignedString([]byte(auth.Secret))
+	require.NoError(t, err)
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	principal, err := authenticate(r)
+	require.NoError(t, err)
+	require.Nil(t, principal)
+
+	r.Header.Set("Authorization", "Bearer "+token)
+	principal, err = authenticate(r)
+	require.NoError(t, err)
+	require.Equal(t, &Principal{Subject: "user-1", Roles: []string{"editor"}}, principal)
+
+	r.Header.Set("Authorization", "Bearer "+token+"x")
+	_, err = authenticate(r)
+	require.Error(t, err)
+}
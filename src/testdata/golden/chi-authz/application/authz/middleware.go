@@ -0,0 +1,58 @@
+package authz
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"example.com/newsroom/auth"
+)
+
+// RequirePermission returns middleware letting requests through to next once the
+// principal making them is allowed permission, with the principal in the context.
+// Routes of every router are http.HandlerFuncs, so it works the same for all of them,
+// like authz.RequirePermission("widgets:write")(createWidget). It panics when
+// permission isn't a resource:action, so mistakes show up when the routes are made.
+func RequirePermission(permission string) func(http.HandlerFunc) http.HandlerFunc {
+	if err := checkPermission(permission); err != nil {
+		panic("authz: " + err.Error())
+	}
+
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			principal, err := check(r, permission)
+			switch {
+			case errors.Is(err, ErrUnauthenticated):
+				writeMessage(w, http.StatusUnauthorized, err.Error())
+			case errors.Is(err, ErrForbidden):
+				writeMessage(w, http.StatusForbidden, err.Error())
+			case err != nil:
+				writeMessage(w, http.StatusInternalServerError, err.Error())
+			case principal != nil:
+				next(w, r.WithContext(WithPrincipal(r.Context(), principal)))
+			default:
+				next(w, r)
+			}
+		}
+	}
+}
+
+// authenticate finds the principal making r with the app's authentication features,
+// every request being anonymous without any
+func authenticate(r *http.Request) (*Principal, error) {
+	claims, err := auth.FromRequest(r)
+	switch {
+	case err == nil:
+		return &Principal{Subject: claims.Subject, Roles: claims.Roles}, nil
+	case !errors.Is(err, auth.ErrNoToken):
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func writeMessage(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"message": message})
+}
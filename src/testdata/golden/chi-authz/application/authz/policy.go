@@ -0,0 +1,163 @@
+package authz
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Wildcard is the permission granting every other, and the action granting
+// every action on a resource, like widgets:*
+const Wildcard = "*"
+
+// Role is a set of permissions, along with those of the roles it inherits
+type Role struct {
+	Inherits    []string `yaml:"inherits"`
+	Permissions []string `yaml:"permissions"`
+}
+
+// Policy is the roles of the app and what they're allowed to do. It can be
+// declared in Go as well as YAML, as long as it's checked with Compile.
+type Policy struct {
+	Roles map[string]Role `yaml:"roles"`
+
+	// granted is every permission of each role, its inherited ones included
+	granted map[string][]string
+}
+
+// LoadPolicy reads the policy in the YAML file at path
+func LoadPolicy(path string) (*Policy, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the policy file: %w", err)
+	}
+
+	return ParsePolicy(b)
+}
+
+// ParsePolicy reads a policy from YAML
+func ParsePolicy(b []byte) (*Policy, error) {
+	p := &Policy{}
+	if err := yaml.Unmarshal(b, p); err != nil {
+		return nil, fmt.Errorf("unable to parse the policy: %w", err)
+	}
+
+	if err := p.Compile(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Compile checks the permissions and inherited roles of p, and works out
+// what each role is allowed to do
+func (p *Policy) Compile() error {
+	if len(p.Roles) == 0 {
+		return errors.New("the policy has no roles")
+	}
+
+	granted := make(map[string][]string, len(p.Roles))
+	for name, role := range p.Roles {
+		for _, permission := range role.Permissions {
+			if err := checkPermission(permission); err != nil {
+				return fmt.Errorf("role %s: %w", name, err)
+			}
+		}
+
+		permissions, err := p.resolve(name, nil)
+		if err != nil {
+			return err
+		}
+
+		slices.Sort(permissions)
+		granted[name] = slices.Compact(permissions)
+	}
+
+	p.granted = granted
+	return nil
+}
+
+// resolve returns the permissions of the role called name and those it inherits,
+// path being the roles inheriting it, to find cycles
+func (p *Policy) resolve(name string, path []string) ([]string, error) {
+	if slices.Contains(path, name) {
+		return nil, fmt.Errorf("role %s inherits itself, through %s", name, strings.Join(append(path, name), " -> "))
+	}
+
+	role, ok := p.Roles[name]
+	if !ok {
+		return nil, fmt.Errorf("role %s inherits the unknown role %s", path[len(path)-1], name)
+	}
+
+	permissions := slices.Clone(role.Permissions)
+	for _, inherited := range role.Inherits {
+		more, err := p.resolve(inherited, append(path, name))
+		if err != nil {
+			return nil, err
+		}
+
+		permissions = append(permissions, more...)
+	}
+
+	return permissions, nil
+}
+
+// Allows reports whether any of roles has permission, unknown roles having none
+func (p *Policy) Allows(roles []string, permission string) bool {
+	for _, role := range roles {
+		for _, granted := range p.granted[role] {
+			if matches(granted, permission) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Grants reports whether any role has permission, to catch permissions that
+// are required but were never granted, like typos
+func (p *Policy) Grants(permission string) bool {
+	for _, permissions := range p.granted {
+		for _, granted := range permissions {
+			if matches(granted, permission) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Permissions returns every permission of role, its inherited ones included
+func (p *Policy) Permissions(role string) []string {
+	return slices.Clone(p.granted[role])
+}
+
+// checkPermission makes sure permission is a resource:action, or a wildcard
+func checkPermission(permission string) error {
+	if permission == Wildcard {
+		return nil
+	}
+
+	resource, action, ok := strings.Cut(permission, ":")
+	if !ok || resource == "" || action == "" || strings.Contains(action, ":") || resource == Wildcard {
+		return fmt.Errorf("invalid permission %q, it needs to be resource:action, like widgets:read", permission)
+	}
+
+	return nil
+}
+
+// matches reports whether the granted permission covers permission
+func matches(granted, permission string) bool {
+	if granted == Wildcard || granted == permission {
+		return true
+	}
+
+	resource, action, _ := strings.Cut(granted, ":")
+	return action == Wildcard && strings.HasPrefix(permission, resource+":")
+}
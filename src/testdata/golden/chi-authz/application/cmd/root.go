@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"example.com/newsroom/actions"
+	"example.com/newsroom/app"
+	"example.com/newsroom/auth"
+	"example.com/newsroom/authz"
+	"example.com/newsroom/health"
+	"example.com/newsroom/middleware"
+	"example.com/newsroom/models"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+const envPrefix = ""
+
+var cfgFile string
+
+var runLambda bool
+
+// rootCmd represents the base command when called without any subcommands
+var rootCmd = &cobra.Command{
+	Use:   "testing",
+	Short: "Newsroom is an API framework for Go.",
+	Long:  "Newsroom is an API framework for Go.",
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// Return values from viper back to cobra if needed
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			if !f.Changed && viper.IsSet(f.Name) {
+				if s, ok := f.Value.(pflag.SliceValue); ok {
+					s.Replace(viper.GetStringSlice(f.Name))
+					return
+				}
+				f.Value.Set(viper.GetString(f.Name))
+			}
+		})
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		defer func() {
+			if err := models.Close(); err != nil {
+				log.Println("unable to close the database:", err)
+			}
+		}()
+
+		if err := auth.Init(); err != nil {
+			return err
+		}
+
+		if err := authz.Init(); err != nil {
+			return err
+		}
+
+		if runLambda {
+			lambda.StartWithOptions(actions.Lambda(), lambda.WithContext(ctx))
+			return nil
+		}
+
+		return serve(ctx)
+	},
+}
+
+// Execute adds all child commands to the root command and sets flags appropriately.
+// This is called by main.main(). It only needs to happen once to the rootCmd.
+func Execute() {
+	err := rootCmd.Execute()
+	if err != nil {
+		os.Exit(1)
+	}
+}
+
+func init() {
+	log.SetOutput(os.Stdout)
+	cobra.OnInitialize(initConfig)
+
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is config.yaml)")
+	rootCmd.PersistentFlags().StringVar(&app.AppName, "app-name", "Newsroom", "application name")
+	rootCmd.PersistentFlags().StringVar(&app.DatabaseDsn, "database-dsn", "", "database dsn")
+
+	rootCmd.PersistentFlags().StringVar(&app.Host, "host", "", "host to listen on, all interfaces if blank")
+	rootCmd.PersistentFlags().StringVar(&app.Port, "port", "8080", "port to listen on")
+	rootCmd.PersistentFlags().DurationVar(&app.ReadTimeout, "read-timeout", 15*time.Second, "how long reading a request can take")
+	rootCmd.PersistentFlags().DurationVar(&app.WriteTimeout, "write-timeout", time.Minute, "how long writing a response can take")
+	rootCmd.PersistentFlags().DurationVar(&app.IdleTimeout, "idle-timeout", 2*time.Minute, "how long idle connections are kept open")
+	rootCmd.PersistentFlags().DurationVar(&app.ShutdownTimeout, "shutdown-timeout", 30*time.Second, "how long requests in flight have to finish on shutdown")
+
+	rootCmd.PersistentFlags().StringSliceVar(&middleware.AllowedOrigins, "cors-origins", nil, "origins allowed to make cross-origin requests, * allows any")
+	rootCmd.PersistentFlags().Int64Var(&middleware.MaxBodyBytes, "max-body-bytes", middleware.MaxBodyBytes, "largest request body accepted, in bytes")
+	rootCmd.PersistentFlags().DurationVar(&middleware.RequestTimeout, "request-timeout", middleware.RequestTimeout, "how long a request's context lasts")
+	rootCmd.PersistentFlags().DurationVar(&health.Timeout, "health-timeout", health.Timeout, "how long each readiness check can take")
+	rootCmd.PersistentFlags().BoolVar(&middleware.TrustProxy, "trust-proxy", false, "take the client's address from the headers set by a proxy in front of the app")
+
+	rootCmd.PersistentFlags().StringVar(&auth.Secret, "auth-secret", "", "secret HS256 tokens are signed with")
+	rootCmd.PersistentFlags().StringVar(&auth.JWKSFile, "auth-jwks-file", "", "JSON Web Key Set file with the public keys RS256 tokens are signed with")
+	rootCmd.PersistentFlags().StringVar(&auth.Issuer, "auth-issuer", "", "issuer tokens have to come from, any if blank")
+	rootCmd.PersistentFlags().StringSliceVar(&auth.Audience, "auth-audience", nil, "audiences tokens have to be for, any if blank")
+	rootCmd.PersistentFlags().DurationVar(&auth.Leeway, "auth-leeway", auth.Leeway, "clock skew allowed when checking the times in tokens")
+
+	rootCmd.PersistentFlags().StringVar(&authz.PolicyFile, "authz-policy", "", "YAML policy file used instead of the built-in one")
+
+	// The Lambda runtime sets AWS_LAMBDA_RUNTIME_API, so the flag isn't needed there
+	rootCmd.PersistentFlags().BoolVarP(&runLambda, "lambda", "l", os.Getenv("AWS_LAMBDA_RUNTIME_API") != "", "whether to run as a lambda or not")
+
+	err := viper.BindPFlags(rootCmd.PersistentFlags())
+	cobra.CheckErr(err)
+
+	app.Init()
+	actions.Init()
+	models.Init()
+}
+
+// initConfig reads in config file and ENV variables if set.
+func initConfig() {
+	if cfgFile != "" {
+		// Use config file from the flag.
+		viper.SetConfigFile(cfgFile)
+	} else {
+		// Find home directory.
+		dir, err := os.Getwd()
+		cobra.CheckErr(err)
+
+		// Search config in home directory with name ".makego" (without extension).
+		viper.AddConfigPath(dir)
+		viper.SetConfigType("yaml")
+		viper.SetConfigName("config")
+	}
+
+	viper.SetEnvPrefix(envPrefix)
+
+	// Environment variables can't have dashes in them,
+	// so bind them to their equivalent keys with underscores
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
+
+	viper.AutomaticEnv() // read in environment variables that match
+
+	// If a config file is found, read it in.
+	if err := viper.ReadInConfig(); err == nil {
+		log.Println("Using config file:", viper.ConfigFileUsed())
+	}
+}
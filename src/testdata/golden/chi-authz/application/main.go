@@ -0,0 +1,7 @@
+package main
+
+import "example.com/newsroom/cmd"
+
+func main() {
+	cmd.Execute()
+}
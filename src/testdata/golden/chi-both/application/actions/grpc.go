@@ -0,0 +1,73 @@
+package actions
+
+import (
+	"context"
+
+	"example.com/chigrpc/health"
+	"example.com/chigrpc/middleware"
+	"example.com/chigrpc/proto/widgets/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// GRPC creates the gRPC server with the app's services,
+// along with the health and reflection services
+func GRPC() *grpc.Server {
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(middleware.UnaryAccessLog, middleware.UnaryRecover),
+		grpc.ChainStreamInterceptor(middleware.StreamAccessLog, middleware.StreamRecover),
+	)
+
+	widgetsv1.RegisterWidgetServiceServer(srv, &widgetServer{})
+	grpc_health_v1.RegisterHealthServer(srv, &healthServer{srv: srv})
+	reflection.Register(srv)
+
+	return srv
+}
+
+// widgetServer implements the example WidgetService, replace it with your own
+type widgetServer struct {
+	widgetsv1.UnimplementedWidgetServiceServer
+}
+
+var widgets = []*widgetsv1.Widget{
+	{Id: 1, Name: "Sprocket"},
+	{Id: 2, Name: "Gear"},
+}
+
+func (s *widgetServer) ListWidgets(ctx context.Context, req *widgetsv1.ListWidgetsRequest) (*widgetsv1.ListWidgetsResponse, error) {
+	return &widgetsv1.ListWidgetsResponse{Widgets: widgets}, nil
+}
+
+func (s *widgetServer) GetWidget(ctx context.Context, req *widgetsv1.GetWidgetRequest) (*widgetsv1.Widget, error) {
+	for _, widget := range widgets {
+		if widget.Id == req.Id {
+			return widget, nil
+		}
+	}
+
+	return nil, status.Errorf(codes.NotFound, "widget %d not found", req.Id)
+}
+
+// healthServer answers health checks with health.Ready,
+// for the server as a whole and each of its services
+type healthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+	srv *grpc.Server
+}
+
+func (h *healthServer) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	if _, ok := h.srv.GetServiceInfo()[req.Service]; req.Service != "" && !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown service %s", req.Service)
+	}
+
+	res := &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}
+	if ready, _ := health.Ready(ctx); !ready {
+		res.Status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}
+
+	return res, nil
+}
@@ -0,0 +1,173 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"example.com/chigrpc/health"
+	"example.com/chigrpc/middleware"
+	"example.com/chigrpc/proto/widgets/v1"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// GRPCSuite calls the gRPC server through an in-memory connection
+type GRPCSuite struct {
+	conn *grpc.ClientConn
+
+	suite.Suite
+	*require.Assertions
+}
+
+func (gs *GRPCSuite) SetupSuite() {
+	lis := bufconn.Listen(1 << 20)
+	srv := GRPC()
+	go srv.Serve(lis)
+	gs.T().Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(gs.T(), err)
+	gs.T().Cleanup(func() { conn.Close() })
+
+	gs.conn = conn
+}
+
+func (gs *GRPCSuite) SetupTest() {
+	gs.Assertions = require.New(gs.T())
+}
+
+// Test_GRPCSuite runs the test suite
+func Test_GRPCSuite(t *testing.T) {
+	suite.Run(t, &GRPCSuite{})
+}
+
+func (gs *GRPCSuite) Test_listWidgets() {
+	res, err := widgetsv1.NewWidgetServiceClient(gs.conn).ListWidgets(context.Background(), &widgetsv1.ListWidgetsRequest{})
+	gs.NoError(err)
+
+	var names []string
+	for _, widget := range res.Widgets {
+		names = append(names, widget.Name)
+	}
+	gs.Equal([]string{"Sprocket", "Gear"}, names)
+}
+
+func (gs *GRPCSuite) Test_getWidget() {
+	testCases := []struct {
+		name     string
+		id       int64
+		want     string
+		wantCode codes.Code
+	}{
+		{
+			name: "found",
+			id:   2,
+			want: "Gear",
+		},
+		{
+			name:     "not found",
+			id:       3,
+			wantCode: codes.NotFound,
+		},
+	}
+	for _, tC := range testCases {
+		gs.Run(tC.name, func() {
+			res, err := widgetsv1.NewWidgetServiceClient(gs.conn).GetWidget(context.Background(), &widgetsv1.GetWidgetRequest{Id: tC.id})
+
+			gs.Equal(tC.wantCode, status.Code(err))
+			gs.Equal(tC.want, res.GetName())
+		})
+	}
+}
+
+func (gs *GRPCSuite) Test_health() {
+	defer func(checks []health.Check) { health.Checks = checks }(health.Checks)
+
+	ok := func(ctx context.Context) error { return nil }
+	failing := func(ctx context.Context) error { return errors.New("connection refused") }
+
+	testCases := []struct {
+		name       string
+		service    string
+		check      func(ctx context.Context) error
+		wantStatus grpc_health_v1.HealthCheckResponse_ServingStatus
+		wantCode   codes.Code
+	}{
+		{
+			name:       "serving",
+			check:      ok,
+			wantStatus: grpc_health_v1.HealthCheckResponse_SERVING,
+		},
+		{
+			name:       "service",
+			service:    widgetsv1.WidgetService_ServiceDesc.ServiceName,
+			check:      ok,
+			wantStatus: grpc_health_v1.HealthCheckResponse_SERVING,
+		},
+		{
+			name:       "failing check",
+			check:      failing,
+			wantStatus: grpc_health_v1.HealthCheckResponse_NOT_SERVING,
+		},
+		{
+			name:     "unknown service",
+			service:  "unknown.v1.Service",
+			check:    ok,
+			wantCode: codes.NotFound,
+		},
+	}
+	for _, tC := range testCases {
+		gs.Run(tC.name, func() {
+			health.Checks = []health.Check{
+				{Name: "database", Check: tC.check},
+			}
+
+			res, err := grpc_health_v1.NewHealthClient(gs.conn).Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: tC.service})
+
+			gs.Equal(tC.wantCode, status.Code(err))
+			gs.Equal(tC.wantStatus, res.GetStatus())
+		})
+	}
+}
+
+func (gs *GRPCSuite) Test_reflection() {
+	stream, err := grpc_reflection_v1.NewServerReflectionClient(gs.conn).ServerReflectionInfo(context.Background())
+	gs.NoError(err)
+
+	gs.NoError(stream.Send(&grpc_reflection_v1.ServerReflectionRequest{
+		MessageRequest: &grpc_reflection_v1.ServerReflectionRequest_ListServices{},
+	}))
+	res, err := stream.Recv()
+	gs.NoError(err)
+	gs.NoError(stream.CloseSend())
+
+	var services []string
+	for _, service := range res.GetListServicesResponse().GetService() {
+		services = append(services, service.Name)
+	}
+	gs.Contains(services, widgetsv1.WidgetService_ServiceDesc.ServiceName)
+	gs.Contains(services, grpc_health_v1.Health_ServiceDesc.ServiceName)
+}
+
+func (gs *GRPCSuite) Test_recover() {
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.v1.Test/Panic"}
+	_, err := middleware.UnaryRecover(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		panic("recovered by middleware.UnaryRecover")
+	})
+
+	gs.Equal(codes.Internal, status.Code(err))
+}
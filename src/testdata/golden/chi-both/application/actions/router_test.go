@@ -0,0 +1,81 @@
+package actions
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	appmiddleware "example.com/chigrpc/middleware"
+)
+
+func (as *ActionSuite) Test_middleware() {
+	as.router.Get("/test/request-id", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(middleware.GetReqID(r.Context())))
+	})
+	as.router.Get("/test/panic", func(w http.ResponseWriter, r *http.Request) {
+		panic("recovered by middleware.Recoverer")
+	})
+
+	testCases := []struct {
+		name     string
+		path     string
+		want     int
+		wantBody bool
+	}{
+		{
+			name:     "request id",
+			path:     "/test/request-id",
+			want:     http.StatusOK,
+			wantBody: true,
+		},
+		{
+			name: "recoverer",
+			path: "/test/panic",
+			want: http.StatusInternalServerError,
+		},
+		{
+			name: "unknown api version",
+			path: "/api/v0/widgets",
+			want: http.StatusNotFound,
+		},
+	}
+	for _, tC := range testCases {
+		as.Run(tC.name, func() {
+			req, _ := http.NewRequest(http.MethodGet, tC.path, nil)
+			res := as.serve(req)
+
+			as.Equal(tC.want, res.StatusCode)
+			if tC.wantBody {
+				body, err := io.ReadAll(res.Body)
+				as.NoError(err)
+				as.NotEmpty(body)
+			}
+		})
+	}
+}
+
+func (as *ActionSuite) Test_realIP() {
+	defer func(trust bool) { appmiddleware.TrustProxy = trust }(appmiddleware.TrustProxy)
+
+	as.router.Get("/test/ip", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.RemoteAddr))
+	})
+
+	for _, trust := range []bool{false, true} {
+		appmiddleware.TrustProxy = trust
+
+		req, _ := http.NewRequest(http.MethodGet, "/test/ip", nil)
+		req.RemoteAddr = "192.0.2.1:1234"
+		req.Header.Set("X-Real-IP", "198.51.100.1")
+		res := as.serve(req)
+
+		body, err := io.ReadAll(res.Body)
+		as.NoError(err)
+		if trust {
+			as.Equal("198.51.100.1", string(body))
+		} else {
+			as.Equal("192.0.2.1:1234", string(body))
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"context"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"example.com/chigrpc/actions"
+	"example.com/chigrpc/app"
+	"example.com/chigrpc/models"
+	"github.com/spf13/cobra"
+)
+
+// serveGRPCCmd serves the gRPC services, run alongside the app to serve both
+var serveGRPCCmd = &cobra.Command{
+	Use:   "serve-grpc",
+	Short: "Serve the gRPC services.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		defer func() {
+			if err := models.Close(); err != nil {
+				log.Println("unable to close the database:", err)
+			}
+		}()
+
+		return serveGRPC(ctx)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(serveGRPCCmd)
+}
+
+// serveGRPC serves the gRPC services until ctx is done, then gives the
+// calls in flight app.ShutdownTimeout to finish before stopping them
+func serveGRPC(ctx context.Context) error {
+	lis, err := net.Listen("tcp", net.JoinHostPort(app.Host, app.GRPCPort))
+	if err != nil {
+		return err
+	}
+
+	srv := actions.GRPC()
+	errs := make(chan error, 1)
+	go func() {
+		log.Println("serving gRPC on", lis.Addr())
+		errs <- srv.Serve(lis)
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Println("shutting down gRPC")
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(app.ShutdownTimeout):
+		srv.Stop()
+	}
+
+	// Serve returns nil once the server is stopped
+	return <-errs
+}
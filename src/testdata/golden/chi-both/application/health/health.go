@@ -0,0 +1,162 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"example.com/chigrpc/app"
+	"example.com/chigrpc/models"
+)
+
+// Check is a dependency the app needs to be ready to serve requests
+type Check struct {
+	Name string
+	// How long the check can take, Timeout if zero
+	Timeout time.Duration
+	Check   func(ctx context.Context) error
+}
+
+// CheckResult is how a check went, as reported by Ready
+type CheckResult struct {
+	Status   string `json:"status"`
+	Duration string `json:"duration"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Timeout is how long a check can take when it doesn't set its own
+var Timeout = 2 * time.Second
+
+// Checks are run by Ready, add any other dependencies of the app here
+var Checks = []Check{
+	{Name: "database", Check: models.Ping},
+}
+
+// Liveness reports that the app is up, without checking its dependencies
+func Liveness() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+}
+
+// Readiness responds with the results of the checks, and
+// 503 Service Unavailable when any of them fail
+func Readiness() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ready, results := Ready(r.Context())
+
+		status, code := "ok", http.StatusOK
+		if !ready {
+			status, code = "unavailable", http.StatusServiceUnavailable
+		}
+
+		writeJSON(w, code, map[string]any{"status": status, "checks": results})
+	})
+}
+
+// Ready runs the checks at the same time, reporting whether they all passed
+func Ready(ctx context.Context) (bool, map[string]CheckResult) {
+	results := make(map[string]CheckResult, len(Checks))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, c := range Checks {
+		wg.Add(1)
+		go func(c Check) {
+			defer wg.Done()
+			result := run(ctx, c)
+
+			mu.Lock()
+			results[c.Name] = result
+			mu.Unlock()
+		}(c)
+	}
+	wg.Wait()
+
+	ready := true
+	for _, result := range results {
+		if result.Status != "ok" {
+			ready = false
+		}
+	}
+
+	return ready, results
+}
+
+// Version reports the version of the app and how it was built
+func Version() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, buildInfo())
+	})
+}
+
+// run runs c with its timeout
+func run(ctx context.Context, c Check) CheckResult {
+	timeout := c.Timeout
+	if timeout == 0 {
+		timeout = Timeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	errs := make(chan error, 1)
+	go func() {
+		errs <- c.Check(ctx)
+	}()
+
+	// Don't wait on checks that ignore their context
+	var err error
+	select {
+	case err = <-errs:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result := CheckResult{Status: "ok", Duration: time.Since(start).String()}
+	if err != nil {
+		result.Status = "error"
+		result.Error = err.Error()
+	}
+
+	return result
+}
+
+// buildInfo is the version set with -ldflags, and what Go recorded when building
+func buildInfo() map[string]string {
+	info := map[string]string{"version": app.Version}
+
+	build, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+
+	info["go"] = build.GoVersion
+	if info["version"] == "" {
+		info["version"] = build.Main.Version
+	}
+
+	for _, setting := range build.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			info["commit"] = setting.Value
+		case "vcs.time":
+			info["buildTime"] = setting.Value
+		case "vcs.modified":
+			info["modified"] = setting.Value
+		}
+	}
+
+	return info
+}
+
+// writeJSON writes body as the JSON response with status
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
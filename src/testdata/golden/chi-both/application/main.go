@@ -0,0 +1,7 @@
+package main
+
+import "example.com/chigrpc/cmd"
+
+func main() {
+	cmd.Execute()
+}
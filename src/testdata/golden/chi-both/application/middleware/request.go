@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// Recover is chi's own middleware
+var Recover = middleware.Recoverer
+
+// TrustProxy is whether RealIP can trust the headers a proxy in front of the app sets, set by --trust-proxy
+var TrustProxy bool
+
+// RealIP is chi's RealIP when TrustProxy is set, taking the client's address from the
+// X-Forwarded-For, X-Real-IP or True-Client-IP headers. Clients can send those themselves,
+// so without a proxy replacing them the address is left as the one they connected from.
+func RealIP(next http.Handler) http.Handler {
+	real := middleware.RealIP(next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if TrustProxy {
+			real.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequestID is chi's RequestID, which also adds the ID to the response
+func RequestID(next http.Handler) http.Handler {
+	return middleware.RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(RequestIDHeader, GetRequestID(r.Context()))
+		next.ServeHTTP(w, r)
+	}))
+}
+
+// GetRequestID returns the ID RequestID gave the request
+func GetRequestID(ctx context.Context) string {
+	return middleware.GetReqID(ctx)
+}
+
+// AccessLog logs each request once it's done, with slog rather than chi's Logger
+func AccessLog(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		slog.Info("request",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", status,
+			"duration", time.Since(start),
+			"request_id", GetRequestID(r.Context()),
+		)
+	})
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"context"
+	"log"
+
+	"example.com/chigrpc/app"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+)
+
+var DB *gorm.DB
+
+func Init() {
+	if err := connect(); err != nil {
+		log.Fatalln(err)
+	}
+}
+
+func connect() error {
+	var err error
+	DB, err = gorm.Open(mysql.Open(app.DatabaseDsn), &gorm.Config{})
+	return err
+}
+
+// Close closes the database connection
+func Close() error {
+	sqlDB, err := DB.DB()
+	if err != nil {
+		return err
+	}
+
+	return sqlDB.Close()
+}
+
+// Ping checks that the database can be reached, for the readiness check
+func Ping(ctx context.Context) error {
+	sqlDB, err := DB.DB()
+	if err != nil {
+		return err
+	}
+
+	return sqlDB.PingContext(ctx)
+}
@@ -0,0 +1,132 @@
+/*
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice,
+   this list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+3. Neither the name of the copyright holder nor the names of its contributors
+   may be used to endorse or promote products derived from this software
+   without specific prior written permission.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"example.com/chi/app"
+	"example.com/chi/health"
+)
+
+func (as *ActionSuite) Test_healthz() {
+	req, _ := http.NewRequest(http.MethodGet, "/healthz", nil)
+	res := as.serve(req)
+
+	as.Equal(http.StatusOK, res.StatusCode)
+
+	var body map[string]string
+	as.NoError(json.NewDecoder(res.Body).Decode(&body))
+	as.Equal("ok", body["status"])
+}
+
+func (as *ActionSuite) Test_readyz() {
+	defer func(checks []health.Check) { health.Checks = checks }(health.Checks)
+
+	ok := func(ctx context.Context) error { return nil }
+	slow := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	testCases := []struct {
+		name       string
+		checks     []health.Check
+		wantStatus int
+		wantErrors map[string]string
+	}{
+		{
+			name: "ready",
+			checks: []health.Check{
+				{Name: "database", Check: ok},
+				{Name: "cache", Check: ok},
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "failing check",
+			checks: []health.Check{
+				{Name: "database", Check: ok},
+				{Name: "cache", Check: func(ctx context.Context) error { return errors.New("connection refused") }},
+			},
+			wantStatus: http.StatusServiceUnavailable,
+			wantErrors: map[string]string{"cache": "connection refused"},
+		},
+		{
+			name: "check timeout",
+			checks: []health.Check{
+				{Name: "database", Timeout: 10 * time.Millisecond, Check: slow},
+			},
+			wantStatus: http.StatusServiceUnavailable,
+			wantErrors: map[string]string{"database": context.DeadlineExceeded.Error()},
+		},
+	}
+	for _, tC := range testCases {
+		as.Run(tC.name, func() {
+			health.Checks = tC.checks
+
+			req, _ := http.NewRequest(http.MethodGet, "/readyz", nil)
+			res := as.serve(req)
+
+			as.Equal(tC.wantStatus, res.StatusCode)
+
+			var body struct {
+				Status string
+				Checks map[string]health.CheckResult
+			}
+			as.NoError(json.NewDecoder(res.Body).Decode(&body))
+			as.Len(body.Checks, len(tC.checks))
+
+			for _, c := range tC.checks {
+				as.Equal(tC.wantErrors[c.Name], body.Checks[c.Name].Error, c.Name)
+			}
+		})
+	}
+}
+
+func (as *ActionSuite) Test_version() {
+	defer func(version string) { app.Version = version }(app.Version)
+	app.Version = "v1.2.3"
+
+	req, _ := http.NewRequest(http.MethodGet, "/version", nil)
+	res := as.serve(req)
+
+	as.Equal(http.StatusOK, res.StatusCode)
+
+	var body map[string]string
+	as.NoError(json.NewDecoder(res.Body).Decode(&body))
+	as.Equal("v1.2.3", body["version"])
+	as.NotEmpty(body["go"])
+}
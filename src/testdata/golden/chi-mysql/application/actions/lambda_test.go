@@ -0,0 +1,71 @@
+/*
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice,
+   this list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+3. Neither the name of the copyright holder nor the names of its contributors
+   may be used to endorse or promote products derived from this software
+   without specific prior written permission.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// lambdaCase is an API Gateway proxy event, and what the response to it should be
+type lambdaCase struct {
+	Request  events.APIGatewayProxyRequest
+	Status   int
+	Contains string
+}
+
+// Test_Lambda sends each event in testdata/lambda through the Lambda handler,
+// the same way API Gateway would, add a file there to test another route
+func (as *ActionSuite) Test_Lambda() {
+	files, err := filepath.Glob(filepath.Join("testdata", "lambda", "*.json"))
+	as.NoError(err)
+	as.NotEmpty(files)
+
+	handler := Lambda()
+	for _, file := range files {
+		as.Run(filepath.Base(file), func() {
+			contents, err := os.ReadFile(file)
+			as.NoError(err)
+
+			var tC lambdaCase
+			as.NoError(json.Unmarshal(contents, &tC))
+
+			res, err := handler(context.Background(), tC.Request)
+			as.NoError(err)
+			as.Equal(tC.Status, res.StatusCode)
+			as.Contains(res.Body, tC.Contains)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+/*
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice,
+   this list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+3. Neither the name of the copyright holder nor the names of its contributors
+   may be used to endorse or promote products derived from this software
+   without specific prior written permission.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package actions
+
+import (
+	"net/http"
+	"strings"
+
+	"example.com/chi/middleware"
+)
+
+func (as *ActionSuite) Test_requestID() {
+	req, _ := http.NewRequest(http.MethodGet, "/", nil)
+	res := as.serve(req)
+	as.NotEmpty(res.Header.Get(middleware.RequestIDHeader))
+
+	req, _ = http.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(middleware.RequestIDHeader, "from-client")
+	res = as.serve(req)
+	as.Equal("from-client", res.Header.Get(middleware.RequestIDHeader))
+}
+
+func (as *ActionSuite) Test_cors() {
+	defer func(origins []string) {
+		middleware.AllowedOrigins = origins
+		as.router = App()
+	}(middleware.AllowedOrigins)
+
+	middleware.AllowedOrigins = []string{"https://example.com"}
+	as.router = App()
+
+	testCases := []struct {
+		name       string
+		method     string
+		origin     string
+		wantOrigin string
+	}{
+		{
+			name:       "allowed",
+			method:     http.MethodGet,
+			origin:     "https://example.com",
+			wantOrigin: "https://example.com",
+		},
+		{
+			name:   "not allowed",
+			method: http.MethodGet,
+			origin: "https://other.example.com",
+		},
+		{
+			name:       "preflight",
+			method:     http.MethodOptions,
+			origin:     "https://example.com",
+			wantOrigin: "https://example.com",
+		},
+	}
+	for _, tC := range testCases {
+		as.Run(tC.name, func() {
+			req, _ := http.NewRequest(tC.method, "/", nil)
+			req.Header.Set("Origin", tC.origin)
+			if tC.method == http.MethodOptions {
+				req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+			}
+			res := as.serve(req)
+
+			as.Equal(tC.wantOrigin, res.Header.Get("Access-Control-Allow-Origin"))
+			if tC.wantOrigin != "" && tC.method == http.MethodGet {
+				as.Contains(res.Header.Get("Access-Control-Expose-Headers"), middleware.RequestIDHeader)
+			}
+		})
+	}
+}
+
+func (as *ActionSuite) Test_bodyLimit() {
+	defer func(limit int64) {
+		middleware.MaxBodyBytes = limit
+		as.router = App()
+	}(middleware.MaxBodyBytes)
+
+	middleware.MaxBodyBytes = 8
+	as.router = App()
+
+	req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader("more than eight bytes"))
+	res := as.serve(req)
+
+	as.Equal(http.StatusRequestEntityTooLarge, res.StatusCode)
+}
@@ -0,0 +1,111 @@
+/*
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice,
+   this list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+3. Neither the name of the copyright holder nor the names of its contributors
+   may be used to endorse or promote products derived from this software
+   without specific prior written permission.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package actions
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	appmiddleware "example.com/chi/middleware"
+)
+
+func (as *ActionSuite) Test_middleware() {
+	as.router.Get("/test/request-id", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(middleware.GetReqID(r.Context())))
+	})
+	as.router.Get("/test/panic", func(w http.ResponseWriter, r *http.Request) {
+		panic("recovered by middleware.Recoverer")
+	})
+
+	testCases := []struct {
+		name     string
+		path     string
+		want     int
+		wantBody bool
+	}{
+		{
+			name:     "request id",
+			path:     "/test/request-id",
+			want:     http.StatusOK,
+			wantBody: true,
+		},
+		{
+			name: "recoverer",
+			path: "/test/panic",
+			want: http.StatusInternalServerError,
+		},
+		{
+			name: "unknown api version",
+			path: "/api/v0/widgets",
+			want: http.StatusNotFound,
+		},
+	}
+	for _, tC := range testCases {
+		as.Run(tC.name, func() {
+			req, _ := http.NewRequest(http.MethodGet, tC.path, nil)
+			res := as.serve(req)
+
+			as.Equal(tC.want, res.StatusCode)
+			if tC.wantBody {
+				body, err := io.ReadAll(res.Body)
+				as.NoError(err)
+				as.NotEmpty(body)
+			}
+		})
+	}
+}
+
+func (as *ActionSuite) Test_realIP() {
+	defer func(trust bool) { appmiddleware.TrustProxy = trust }(appmiddleware.TrustProxy)
+
+	as.router.Get("/test/ip", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.RemoteAddr))
+	})
+
+	for _, trust := range []bool{false, true} {
+		appmiddleware.TrustProxy = trust
+
+		req, _ := http.NewRequest(http.MethodGet, "/test/ip", nil)
+		req.RemoteAddr = "192.0.2.1:1234"
+		req.Header.Set("X-Real-IP", "198.51.100.1")
+		res := as.serve(req)
+
+		body, err := io.ReadAll(res.Body)
+		as.NoError(err)
+		if trust {
+			as.Equal("198.51.100.1", string(body))
+		} else {
+			as.Equal("192.0.2.1:1234", string(body))
+		}
+	}
+}
@@ -0,0 +1,101 @@
+/*
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice,
+   this list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+3. Neither the name of the copyright holder nor the names of its contributors
+   may be used to endorse or promote products derived from this software
+   without specific prior written permission.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Version is a version of the API, served under /api/<Name> by Register
+type Version struct {
+	Name   string
+	Routes []Route
+
+	// When the version was deprecated, and when it will be removed, sent
+	// as the Deprecation and Sunset headers so clients can move on in time
+	Deprecated time.Time
+	Sunset     time.Time
+}
+
+// Route is a method and path of a version, like GET /widgets/{id},
+// the handler reads the path parameters with r.PathValue
+type Route struct {
+	Method  string
+	Path    string
+	Handler http.HandlerFunc
+}
+
+// Versions are the versions of the API. Add a version by copying the latest
+// with a new name, then deprecate the old one by setting Deprecated and Sunset
+var Versions = []Version{
+	{
+		Name: "v1",
+		Routes: []Route{
+			{Method: http.MethodGet, Path: "/widgets", Handler: listWidgets},
+			{Method: http.MethodGet, Path: "/widgets/{id}", Handler: getWidget},
+		},
+	},
+}
+
+// Prefix is where v is served
+func (v Version) Prefix() string {
+	return "/api/" + v.Name
+}
+
+// handler wraps the route's handler in the checks features add, like
+// authorization, and in one telling clients when v is deprecated
+func (v Version) handler(route Route) http.HandlerFunc {
+	h := route.Handler
+
+	if v.Deprecated.IsZero() && v.Sunset.IsZero() {
+		return h
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !v.Deprecated.IsZero() {
+			w.Header().Set("Deprecation", "@"+strconv.FormatInt(v.Deprecated.Unix(), 10))
+		}
+		if !v.Sunset.IsZero() {
+			w.Header().Set("Sunset", v.Sunset.UTC().Format(http.TimeFormat))
+		}
+		h(w, r)
+	}
+}
+
+// writeJSON writes body as the JSON response with status
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
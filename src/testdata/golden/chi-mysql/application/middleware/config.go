@@ -0,0 +1,56 @@
+/*
+All rights reserved.
+
+Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+
+1. Redistributions of source code must retain the above copyright notice,
+   this list of conditions and the following disclaimer.
+
+2. Redistributions in binary form must reproduce the above copyright notice,
+   this list of conditions and the following disclaimer in the documentation
+   and/or other materials provided with the distribution.
+
+3. Neither the name of the copyright holder nor the names of its contributors
+   may be used to endorse or promote products derived from this software
+   without specific prior written permission.
+
+THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS AND CONTRIBUTORS "AS IS"
+AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO, THE
+IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR PURPOSE
+ARE DISCLAIMED. IN NO EVENT SHALL THE COPYRIGHT HOLDER OR CONTRIBUTORS BE
+LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL, EXEMPLARY, OR
+CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO, PROCUREMENT OF
+SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR PROFITS; OR BUSINESS
+INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN
+CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE)
+ARISING IN ANY WAY OUT OF THE USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE
+POSSIBILITY OF SUCH DAMAGE.
+*/
+
+package middleware
+
+import (
+	"slices"
+	"time"
+)
+
+// RequestIDHeader is where the request ID is read from, and written to the response
+const RequestIDHeader = "X-Request-Id"
+
+// Options for the middleware, set by the flags in cmd/root.go
+var (
+	// AllowedOrigins can make cross-origin requests, * allows any origin
+	AllowedOrigins []string
+	// ExposedHeaders are the headers of responses that cross-origin requests can read
+	ExposedHeaders = []string{RequestIDHeader}
+	// MaxBodyBytes is the largest request body accepted
+	MaxBodyBytes int64 = 1 << 20
+	// RequestTimeout is how long a request's context lasts
+	RequestTimeout = 30 * time.Second
+)
+
+// allowOrigin reports whether requests from origin are allowed
+func allowOrigin(origin string) bool {
+	return origin != "" && (slices.Contains(AllowedOrigins, "*") || slices.Contains(AllowedOrigins, origin))
+}
@@ -0,0 +1,35 @@
+// Owned by payments
+
+package actions
+
+import (
+	"example.com/custom/api"
+	"example.com/custom/health"
+	"example.com/custom/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// Init initializes the actions package
+func Init() {
+}
+
+// App Creates and returns the application with routes
+func App() *gin.Engine {
+	app := gin.New()
+	app.Use(
+		middleware.RequestID(),
+		middleware.AccessLog(),
+		middleware.Recover(),
+		middleware.CORS(),
+		middleware.BodyLimit(),
+		middleware.Timeout(),
+	)
+
+	app.GET("/", homeView)
+	app.Any("/healthz", gin.WrapH(health.Liveness()))
+	app.Any("/readyz", gin.WrapH(health.Readiness()))
+	app.Any("/version", gin.WrapH(health.Version()))
+	api.Register(app)
+
+	return app
+}
@@ -0,0 +1,13 @@
+// Owned by payments
+
+package actions
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func homeView(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"message": "Welcome!"})
+}
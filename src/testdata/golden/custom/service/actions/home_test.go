@@ -0,0 +1,19 @@
+// Owned by payments
+
+package actions
+
+import (
+	"io"
+	"net/http"
+)
+
+func (as *ActionSuite) Test_homeView() {
+	req, _ := http.NewRequest(http.MethodGet, "/", nil)
+	res := as.serve(req)
+
+	as.Equal(http.StatusOK, res.StatusCode)
+
+	body, err := io.ReadAll(res.Body)
+	as.NoError(err)
+	as.Contains(string(body), "Welcome!")
+}
@@ -0,0 +1,15 @@
+// Owned by payments
+
+package actions
+
+import (
+	"context"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/awslabs/aws-lambda-go-api-proxy/gin"
+)
+
+// Lambda handles API Gateway proxy events with the app, for running as an AWS Lambda
+func Lambda() func(context.Context, events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return ginadapter.New(App()).ProxyWithContext
+}
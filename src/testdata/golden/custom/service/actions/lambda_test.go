@@ -0,0 +1,43 @@
+// Owned by payments
+
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// lambdaCase is an API Gateway proxy event, and what the response to it should be
+type lambdaCase struct {
+	Request  events.APIGatewayProxyRequest
+	Status   int
+	Contains string
+}
+
+// Test_Lambda sends each event in testdata/lambda through the Lambda handler,
+// the same way API Gateway would, add a file there to test another route
+func (as *ActionSuite) Test_Lambda() {
+	files, err := filepath.Glob(filepath.Join("testdata", "lambda", "*.json"))
+	as.NoError(err)
+	as.NotEmpty(files)
+
+	handler := Lambda()
+	for _, file := range files {
+		as.Run(filepath.Base(file), func() {
+			contents, err := os.ReadFile(file)
+			as.NoError(err)
+
+			var tC lambdaCase
+			as.NoError(json.Unmarshal(contents, &tC))
+
+			res, err := handler(context.Background(), tC.Request)
+			as.NoError(err)
+			as.Equal(tC.Status, res.StatusCode)
+			as.Contains(res.Body, tC.Contains)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+// Owned by payments
+
+package actions
+
+import (
+	"net/http"
+	"strings"
+
+	"example.com/custom/middleware"
+)
+
+func (as *ActionSuite) Test_requestID() {
+	req, _ := http.NewRequest(http.MethodGet, "/", nil)
+	res := as.serve(req)
+	as.NotEmpty(res.Header.Get(middleware.RequestIDHeader))
+
+	req, _ = http.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(middleware.RequestIDHeader, "from-client")
+	res = as.serve(req)
+	as.Equal("from-client", res.Header.Get(middleware.RequestIDHeader))
+}
+
+func (as *ActionSuite) Test_cors() {
+	defer func(origins []string) {
+		middleware.AllowedOrigins = origins
+		as.router = App()
+	}(middleware.AllowedOrigins)
+
+	middleware.AllowedOrigins = []string{"https://example.com"}
+	as.router = App()
+
+	testCases := []struct {
+		name       string
+		method     string
+		origin     string
+		wantOrigin string
+	}{
+		{
+			name:       "allowed",
+			method:     http.MethodGet,
+			origin:     "https://example.com",
+			wantOrigin: "https://example.com",
+		},
+		{
+			name:   "not allowed",
+			method: http.MethodGet,
+			origin: "https://other.example.com",
+		},
+		{
+			name:       "preflight",
+			method:     http.MethodOptions,
+			origin:     "https://example.com",
+			wantOrigin: "https://example.com",
+		},
+	}
+	for _, tC := range testCases {
+		as.Run(tC.name, func() {
+			req, _ := http.NewRequest(tC.method, "/", nil)
+			req.Header.Set("Origin", tC.origin)
+			if tC.method == http.MethodOptions {
+				req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+			}
+			res := as.serve(req)
+
+			as.Equal(tC.wantOrigin, res.Header.Get("Access-Control-Allow-Origin"))
+			if tC.wantOrigin != "" && tC.method == http.MethodGet {
+				as.Contains(res.Header.Get("Access-Control-Expose-Headers"), middleware.RequestIDHeader)
+			}
+		})
+	}
+}
+
+func (as *ActionSuite) Test_bodyLimit() {
+	defer func(limit int64) {
+		middleware.MaxBodyBytes = limit
+		as.router = App()
+	}(middleware.MaxBodyBytes)
+
+	middleware.MaxBodyBytes = 8
+	as.router = App()
+
+	req, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader("more than eight bytes"))
+	res := as.serve(req)
+
+	as.Equal(http.StatusRequestEntityTooLarge, res.StatusCode)
+}
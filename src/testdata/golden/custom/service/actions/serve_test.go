@@ -0,0 +1,16 @@
+// Owned by payments
+
+package actions
+
+import (
+	"net/http"
+	"net/http/httptest"
+)
+
+// serve sends req to the router and returns its response
+func (as *ActionSuite) serve(req *http.Request) *http.Response {
+	w := httptest.NewRecorder()
+	as.router.ServeHTTP(w, req)
+
+	return w.Result()
+}
@@ -0,0 +1,37 @@
+// Owned by payments
+
+package api
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Register adds the routes of every version of the API to app, in a group for each
+func Register(app *gin.Engine) {
+	for _, v := range Versions {
+		group := app.Group(v.Prefix())
+		for _, route := range v.Routes {
+			group.Handle(route.Method, ginPath(route.Path), PathValues(v.handler(route)))
+		}
+	}
+}
+
+var pathParam = regexp.MustCompile(`\{([^/{}]+)\}`)
+
+// ginPath writes the parameters of path the way gin does, like /widgets/:id
+func ginPath(path string) string {
+	return pathParam.ReplaceAllString(path, ":$1")
+}
+
+// PathValues adapts h, passing the path parameters on to be read with r.PathValue
+func PathValues(h http.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, p := range c.Params {
+			c.Request.SetPathValue(p.Key, p.Value)
+		}
+		h(c.Writer, c.Request)
+	}
+}
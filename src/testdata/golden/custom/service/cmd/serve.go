@@ -0,0 +1,52 @@
+// Owned by payments
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+
+	"example.com/custom/actions"
+	"example.com/custom/app"
+)
+
+// serve runs the app until ctx is done, then gives the requests
+// in flight app.ShutdownTimeout to finish before returning
+func serve(ctx context.Context) error {
+	srv := &http.Server{
+		Addr:         net.JoinHostPort(app.Host, app.Port),
+		Handler:      actions.App(),
+		ReadTimeout:  app.ReadTimeout,
+		WriteTimeout: app.WriteTimeout,
+		IdleTimeout:  app.IdleTimeout,
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		log.Println("listening on", srv.Addr)
+		errs <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Println("shutting down")
+	ctx, cancel := context.WithTimeout(context.Background(), app.ShutdownTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(ctx); err != nil {
+		return err
+	}
+
+	if err := <-errs; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
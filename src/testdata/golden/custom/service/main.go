@@ -0,0 +1,9 @@
+// Owned by payments
+
+package main
+
+import "example.com/custom/cmd"
+
+func main() {
+	cmd.Execute()
+}
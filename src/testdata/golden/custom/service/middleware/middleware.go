@@ -0,0 +1,82 @@
+// Owned by payments
+
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CORS allows cross-origin requests from AllowedOrigins, answering preflight requests itself
+func CORS() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if !allowOrigin(origin) {
+			return
+		}
+
+		c.Header("Access-Control-Allow-Origin", origin)
+		c.Header("Access-Control-Expose-Headers", strings.Join(ExposedHeaders, ", "))
+		c.Writer.Header().Add("Vary", "Origin")
+
+		if c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != "" {
+			c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+			c.Header("Access-Control-Allow-Headers", c.GetHeader("Access-Control-Request-Headers"))
+			c.AbortWithStatus(http.StatusNoContent)
+		}
+	}
+}
+
+// BodyLimit rejects request bodies larger than MaxBodyBytes
+func BodyLimit() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.ContentLength > MaxBodyBytes {
+			c.AbortWithStatus(http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, MaxBodyBytes)
+	}
+}
+
+// Timeout cancels the request's context after RequestTimeout, unless it's long lived
+func Timeout() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if longLived(c.Request) {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), RequestTimeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// HTTP adapts net/http middleware, like the features bring, to gin. The
+// request goes on to the next handler if the middleware calls its own.
+func HTTP(middleware func(http.Handler) http.Handler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		next := false
+		middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next = true
+			c.Request = r
+			c.Next()
+		})).ServeHTTP(c.Writer, c.Request)
+
+		if !next {
+			c.Abort()
+		}
+	}
+}
+
+// longLived reports whether r opens a WebSocket or an event stream,
+// which stay open for as long as the client does
+func longLived(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket") ||
+		strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+}
@@ -0,0 +1,63 @@
+// Owned by payments
+
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const requestIDKey = "request_id"
+
+// RequestID keeps the request's ID, or makes one, adding it to the context and response
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if id == "" {
+			id = newRequestID()
+		}
+
+		c.Set(requestIDKey, id)
+		c.Header(RequestIDHeader, id)
+	}
+}
+
+// GetRequestID returns the ID RequestID gave the request
+func GetRequestID(c *gin.Context) string {
+	return c.GetString(requestIDKey)
+}
+
+// AccessLog logs each request once it's done
+func AccessLog() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		slog.Info("request",
+			"method", c.Request.Method,
+			"path", c.Request.URL.Path,
+			"status", c.Writer.Status(),
+			"duration", time.Since(start),
+			"request_id", GetRequestID(c),
+		)
+	}
+}
+
+// Recover turns panics into internal server errors
+func Recover() gin.HandlerFunc {
+	return gin.CustomRecovery(func(c *gin.Context, err any) {
+		slog.Error("panic", "error", err, "request_id", GetRequestID(c))
+		c.AbortWithStatus(http.StatusInternalServerError)
+	})
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
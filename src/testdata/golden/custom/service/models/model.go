@@ -0,0 +1,46 @@
+// Owned by payments
+
+package models
+
+import (
+	"context"
+	"log"
+
+	"example.com/custom/app"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+var DB *gorm.DB
+
+func Init() {
+	if err := connect(); err != nil {
+		log.Fatalln(err)
+	}
+}
+
+func connect() error {
+	var err error
+	DB, err = gorm.Open(postgres.Open(app.DatabaseDsn), &gorm.Config{})
+	return err
+}
+
+// Close closes the database connection
+func Close() error {
+	sqlDB, err := DB.DB()
+	if err != nil {
+		return err
+	}
+
+	return sqlDB.Close()
+}
+
+// Ping checks that the database can be reached, for the readiness check
+func Ping(ctx context.Context) error {
+	sqlDB, err := DB.DB()
+	if err != nil {
+		return err
+	}
+
+	return sqlDB.PingContext(ctx)
+}
@@ -0,0 +1,35 @@
+// Owned by payments
+
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type ModelSuite struct {
+	db *gorm.DB
+
+	suite.Suite
+	*require.Assertions
+}
+
+func (as *ModelSuite) SetupTest() {
+	as.Assertions = require.New(as.T())
+}
+
+// Test_ModelSuite runs the test suite
+func Test_ModelSuite(t *testing.T) {
+	if err := connect(); err != nil {
+		panic(err)
+	}
+
+	ms := &ModelSuite{
+		db: DB,
+	}
+
+	suite.Run(t, ms)
+}
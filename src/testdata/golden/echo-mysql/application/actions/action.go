@@ -0,0 +1,33 @@
+package actions
+
+import (
+	"example.com/echo/api"
+	"example.com/echo/health"
+	"example.com/echo/middleware"
+	"github.com/labstack/echo/v4"
+)
+
+// Init initializes the actions package
+func Init() {
+}
+
+// App Creates and returns the application with routes
+func App() *echo.Echo {
+	app := echo.New()
+	app.Use(
+		middleware.RequestID(),
+		middleware.AccessLog(),
+		middleware.Recover(),
+		middleware.CORS(),
+		middleware.BodyLimit(),
+		middleware.Timeout(),
+	)
+
+	app.GET("/", homeView)
+	app.Any("/healthz", echo.WrapHandler(health.Liveness()))
+	app.Any("/readyz", echo.WrapHandler(health.Readiness()))
+	app.Any("/version", echo.WrapHandler(health.Version()))
+	api.Register(app)
+
+	return app
+}
@@ -0,0 +1,11 @@
+package actions
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+func homeView(c echo.Context) error {
+	return c.String(http.StatusOK, "Welcome!")
+}
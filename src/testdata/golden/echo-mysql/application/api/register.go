@@ -0,0 +1,37 @@
+package api
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Register adds the routes of every version of the API to app, in a group for each
+func Register(app *echo.Echo) {
+	for _, v := range Versions {
+		group := app.Group(v.Prefix())
+		for _, route := range v.Routes {
+			group.Add(route.Method, echoPath(route.Path), PathValues(v.handler(route)))
+		}
+	}
+}
+
+var pathParam = regexp.MustCompile(`\{([^/{}]+)\}`)
+
+// echoPath writes the parameters of path the way echo does, like /widgets/:id
+func echoPath(path string) string {
+	return pathParam.ReplaceAllString(path, ":$1")
+}
+
+// PathValues adapts h, passing the path parameters on to be read with r.PathValue
+func PathValues(h http.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		r := c.Request()
+		for i, name := range c.ParamNames() {
+			r.SetPathValue(name, c.ParamValues()[i])
+		}
+		h(c.Response(), r)
+		return nil
+	}
+}
@@ -0,0 +1,7 @@
+package main
+
+import "example.com/echo/cmd"
+
+func main() {
+	cmd.Execute()
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// CORS allows cross-origin requests from AllowedOrigins, answering preflight requests itself
+func CORS() echo.MiddlewareFunc {
+	// echo allows every origin when none are given
+	if len(AllowedOrigins) == 0 {
+		return func(next echo.HandlerFunc) echo.HandlerFunc {
+			return next
+		}
+	}
+
+	return middleware.CORSWithConfig(middleware.CORSConfig{AllowOrigins: AllowedOrigins, ExposeHeaders: ExposedHeaders})
+}
+
+// BodyLimit rejects request bodies larger than MaxBodyBytes
+func BodyLimit() echo.MiddlewareFunc {
+	return middleware.BodyLimit(fmt.Sprintf("%dB", MaxBodyBytes))
+}
+
+// Timeout cancels the request's context after RequestTimeout, unless it's long lived
+func Timeout() echo.MiddlewareFunc {
+	return middleware.ContextTimeoutWithConfig(middleware.ContextTimeoutConfig{
+		Skipper: func(c echo.Context) bool {
+			return longLived(c.Request())
+		},
+		Timeout: RequestTimeout,
+	})
+}
+
+// longLived reports whether r opens a WebSocket or an event stream,
+// which stay open for as long as the client does
+func longLived(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket") ||
+		strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"log/slog"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// RequestID keeps the request's ID, or makes one, adding it to the response
+func RequestID() echo.MiddlewareFunc {
+	return middleware.RequestIDWithConfig(middleware.RequestIDConfig{TargetHeader: RequestIDHeader})
+}
+
+// GetRequestID returns the ID RequestID gave the request
+func GetRequestID(c echo.Context) string {
+	return c.Response().Header().Get(RequestIDHeader)
+}
+
+// AccessLog logs each request once it's done
+func AccessLog() echo.MiddlewareFunc {
+	return middleware.RequestLoggerWithConfig(middleware.RequestLoggerConfig{
+		LogMethod:    true,
+		LogURIPath:   true,
+		LogStatus:    true,
+		LogLatency:   true,
+		LogRequestID: true,
+		LogValuesFunc: func(c echo.Context, v middleware.RequestLoggerValues) error {
+			slog.Info("request",
+				"method", v.Method,
+				"path", v.URIPath,
+				"status", v.Status,
+				"duration", v.Latency,
+				"request_id", v.RequestID,
+			)
+			return nil
+		},
+	})
+}
+
+// Recover turns panics into internal server errors
+func Recover() echo.MiddlewareFunc {
+	return middleware.Recover()
+}
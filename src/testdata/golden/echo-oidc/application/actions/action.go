@@ -0,0 +1,40 @@
+package actions
+
+import (
+	"net/http"
+
+	"example.com/portal/api"
+	"example.com/portal/health"
+	"example.com/portal/middleware"
+	"example.com/portal/oidc"
+	"github.com/labstack/echo/v4"
+)
+
+// Init initializes the actions package
+func Init() {
+}
+
+// App Creates and returns the application with routes
+func App() *echo.Echo {
+	app := echo.New()
+	app.Use(
+		middleware.RequestID(),
+		middleware.AccessLog(),
+		middleware.Recover(),
+		middleware.CORS(),
+		middleware.BodyLimit(),
+		middleware.Timeout(),
+	)
+
+	app.GET("/", homeView)
+	app.Any("/healthz", echo.WrapHandler(health.Liveness()))
+	app.Any("/readyz", echo.WrapHandler(health.Readiness()))
+	app.Any("/version", echo.WrapHandler(health.Version()))
+	api.Register(app)
+	app.Any("/auth/login", echo.WrapHandler(http.HandlerFunc(oidc.Login)))
+	app.Any("/auth/callback", echo.WrapHandler(http.HandlerFunc(oidc.Callback)))
+	app.Any("/auth/logout", echo.WrapHandler(http.HandlerFunc(oidc.Logout)))
+	app.Any("/auth/user", echo.WrapHandler(oidc.Required(oidc.User)))
+
+	return app
+}
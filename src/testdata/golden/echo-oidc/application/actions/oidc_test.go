@@ -0,0 +1,66 @@
+package actions
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"example.com/portal/oidc"
+	"example.com/portal/oidc/oidctest"
+)
+
+func (as *ActionSuite) Test_oidc() {
+	idp, provider, err := oidctest.NewServer("client-id", "client-secret")
+	as.NoError(err)
+	defer idp.Close()
+
+	defer func(issuer, id, secret, redirect, session string) {
+		oidc.Issuer, oidc.ClientID, oidc.ClientSecret, oidc.RedirectURL, oidc.SessionSecret = issuer, id, secret, redirect, session
+	}(oidc.Issuer, oidc.ClientID, oidc.ClientSecret, oidc.RedirectURL, oidc.SessionSecret)
+
+	oidc.Issuer, oidc.ClientID, oidc.ClientSecret = idp.URL, "client-id", "client-secret"
+	oidc.RedirectURL = "http://example.com" + oidc.CallbackPath
+	oidc.SessionSecret = "a-session-secret-that-is-long-enough"
+	as.NoError(oidc.Init())
+
+	req, _ := http.NewRequest(http.MethodGet, "/auth/user", nil)
+	res := as.serve(req)
+	as.Equal(http.StatusUnauthorized, res.StatusCode)
+
+	req, _ = http.NewRequest(http.MethodGet, oidc.LoginPath+"?return_to=/widgets", nil)
+	login := as.serve(req)
+	as.Equal(http.StatusFound, login.StatusCode)
+
+	// The provider signs the user in straight away, sending them back with a code
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	res, err = client.Get(login.Header.Get("Location"))
+	as.NoError(err)
+	res.Body.Close()
+	as.Equal(http.StatusFound, res.StatusCode)
+
+	callback, err := url.Parse(res.Header.Get("Location"))
+	as.NoError(err)
+
+	req, _ = http.NewRequest(http.MethodGet, callback.RequestURI(), nil)
+	for _, c := range login.Cookies() {
+		req.AddCookie(c)
+	}
+	res = as.serve(req)
+	as.Equal(http.StatusSeeOther, res.StatusCode)
+	as.Equal("/widgets", res.Header.Get("Location"))
+
+	req, _ = http.NewRequest(http.MethodGet, "/auth/user", nil)
+	for _, c := range res.Cookies() {
+		if c.MaxAge > 0 {
+			req.AddCookie(c)
+		}
+	}
+	res = as.serve(req)
+	as.Equal(http.StatusOK, res.StatusCode)
+
+	var s oidc.Session
+	as.NoError(json.NewDecoder(res.Body).Decode(&s))
+	as.Equal(provider.User.Email, s.Email)
+}
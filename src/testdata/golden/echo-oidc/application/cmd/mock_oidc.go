@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"example.com/portal/app"
+	"example.com/portal/oidc"
+	"example.com/portal/oidc/oidctest"
+	"github.com/spf13/cobra"
+)
+
+// Options of the mock provider
+var (
+	mockAddr       string
+	mockIssuer     string
+	mockBrowserURL string
+	mockRoles      []string
+)
+
+// mockOIDCCmd runs a provider that signs everyone in without a password, for local development only
+var mockOIDCCmd = &cobra.Command{
+	Use:   "mock-oidc",
+	Short: "Run a mock OpenID Connect provider for local development.",
+	Long: "Run a mock OpenID Connect provider for local development, which signs everyone in as the same user " +
+		"without asking for a password. It accepts the app's --oidc-client-id and --oidc-client-secret.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		provider, err := oidctest.New(mockIssuer, oidc.ClientID, oidc.ClientSecret)
+		if err != nil {
+			return err
+		}
+		provider.BrowserURL = mockBrowserURL
+		provider.User.Roles = mockRoles
+
+		srv := &http.Server{Addr: mockAddr, Handler: provider, ReadHeaderTimeout: 10 * time.Second}
+		errs := make(chan error, 1)
+		go func() {
+			log.Println("serving a mock oidc provider for", mockIssuer, "on", mockAddr)
+			errs <- srv.ListenAndServe()
+		}()
+
+		select {
+		case err := <-errs:
+			return err
+		case <-ctx.Done():
+		}
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), app.ShutdownTimeout)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			return err
+		}
+
+		if err := <-errs; !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	},
+}
+
+func init() {
+	mockOIDCCmd.Flags().StringVar(&mockAddr, "mock-addr", ":9999", "address the mock provider listens on")
+	mockOIDCCmd.Flags().StringVar(&mockIssuer, "mock-issuer", "http://localhost:9999", "URL the app reaches the mock provider at")
+	mockOIDCCmd.Flags().StringVar(&mockBrowserURL, "mock-browser-url", "", "URL browsers reach the mock provider at, the issuer if blank")
+	mockOIDCCmd.Flags().StringSliceVar(&mockRoles, "mock-roles", nil, "roles of the signed in user, in the roles claim")
+	rootCmd.AddCommand(mockOIDCCmd)
+}
@@ -0,0 +1,7 @@
+package main
+
+import "example.com/portal/cmd"
+
+func main() {
+	cmd.Execute()
+}
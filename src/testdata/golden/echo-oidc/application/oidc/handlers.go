@@ -0,0 +1,183 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+// Paths of the handlers, as routed in actions/action.go
+const (
+	LoginPath    = "/auth/login"
+	CallbackPath = "/auth/callback"
+	LogoutPath   = "/auth/logout"
+	// cookiePath scopes the login cookie to the handlers that use it
+	cookiePath = "/auth"
+)
+
+// Login sends the user to the provider to sign in, remembering the local page
+// in ?return_to to send them back to afterwards
+func Login(w http.ResponseWriter, r *http.Request) {
+	c, err := discover(r.Context())
+	if err != nil {
+		log.Println(err)
+		writeMessage(w, http.StatusServiceUnavailable, "the identity provider is unavailable")
+		return
+	}
+
+	l := login{
+		State:    rand.Text(),
+		Nonce:    rand.Text(),
+		Verifier: oauth2.GenerateVerifier(),
+		ReturnTo: localPath(r.URL.Query().Get("return_to")),
+		Expires:  time.Now().Add(loginTTL),
+	}
+	if err := setCookie(w, loginCookie, cookiePath, l, l.Expires); err != nil {
+		log.Println("unable to set the login cookie:", err)
+		writeMessage(w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	http.Redirect(w, r, c.config.AuthCodeURL(l.State, gooidc.Nonce(l.Nonce), oauth2.S256ChallengeOption(l.Verifier)), http.StatusFound)
+}
+
+// Callback is where the provider sends the user back to, it checks the response
+// is to their Login, exchanges the code for an ID token and signs them in with it
+func Callback(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	cookie, err := r.Cookie(loginCookie)
+	if err != nil {
+		writeMessage(w, http.StatusBadRequest, "no sign in is in progress")
+		return
+	}
+	clearCookie(w, loginCookie, cookiePath)
+
+	var l login
+	if err := unseal(loginCookie, cookie.Value, &l); err != nil || time.Now().After(l.Expires) {
+		writeMessage(w, http.StatusBadRequest, "no sign in is in progress")
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(q.Get("state")), []byte(l.State)) != 1 {
+		writeMessage(w, http.StatusBadRequest, "the sign in state doesn't match")
+		return
+	}
+	if e := q.Get("error"); e != "" {
+		writeMessage(w, http.StatusUnauthorized, "the identity provider refused to sign you in: "+e)
+		return
+	}
+
+	c, err := discover(r.Context())
+	if err != nil {
+		log.Println(err)
+		writeMessage(w, http.StatusServiceUnavailable, "the identity provider is unavailable")
+		return
+	}
+
+	ctx := providerContext(r.Context())
+	token, err := c.config.Exchange(ctx, q.Get("code"), oauth2.VerifierOption(l.Verifier))
+	if err != nil {
+		log.Println("unable to exchange the oidc code:", err)
+		writeMessage(w, http.StatusUnauthorized, "unable to sign you in")
+		return
+	}
+
+	raw, _ := token.Extra("id_token").(string)
+	idToken, err := c.verifier.Verify(ctx, raw)
+	if err != nil || subtle.ConstantTimeCompare([]byte(idToken.Nonce), []byte(l.Nonce)) != 1 {
+		log.Println("invalid oidc id token:", err)
+		writeMessage(w, http.StatusUnauthorized, "unable to sign you in")
+		return
+	}
+
+	s := &Session{}
+	if err := idToken.Claims(s); err != nil {
+		log.Println("unable to read the oidc id token claims:", err)
+		writeMessage(w, http.StatusUnauthorized, "unable to sign you in")
+		return
+	}
+	s.Subject, s.Expires = idToken.Subject, time.Now().Add(SessionTTL)
+
+	if err := setCookie(w, sessionCookie, "/", s, s.Expires); err != nil {
+		log.Println("unable to set the session cookie:", err)
+		writeMessage(w, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	http.Redirect(w, r, l.ReturnTo, http.StatusSeeOther)
+}
+
+// Logout signs the user out of the app, though not of the provider. It only
+// accepts POSTs, so other sites can't sign users out with a link or an image.
+func Logout(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeMessage(w, http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
+		return
+	}
+
+	clearCookie(w, sessionCookie, "/")
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+}
+
+// User responds with the session of the signed in user, behind Required
+func User(w http.ResponseWriter, r *http.Request) {
+	s, _ := FromContext(r.Context())
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s)
+}
+
+// Required lets requests from signed in users through to next, with their session
+// in the context. Browsers navigating to a page are sent to sign in and then back,
+// any other requests get 401 Unauthorized, like auth.Required does.
+func Required(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s, err := FromRequest(r)
+		if err != nil {
+			if r.Method == http.MethodGet && strings.Contains(r.Header.Get("Accept"), "text/html") {
+				http.Redirect(w, r, LoginPath+"?"+url.Values{"return_to": {r.URL.RequestURI()}}.Encode(), http.StatusFound)
+				return
+			}
+
+			writeMessage(w, http.StatusUnauthorized, err.Error())
+			return
+		}
+
+		next(w, r.WithContext(WithSession(r.Context(), s)))
+	}
+}
+
+// providerContext is ctx with the client requests to the provider are made with
+func providerContext(ctx context.Context) context.Context {
+	mu.Lock()
+	defer mu.Unlock()
+	return gooidc.ClientContext(ctx, httpClient)
+}
+
+// localPath is p when it's a path on this site, or the home page, so signing in
+// can't be used to send users on to another site
+func localPath(p string) string {
+	u, err := url.Parse(p)
+	if err != nil || !strings.HasPrefix(p, "/") || strings.HasPrefix(p, "//") || strings.HasPrefix(p, "/\\") ||
+		u.Scheme != "" || u.Host != "" {
+		return "/"
+	}
+
+	return p
+}
+
+func writeMessage(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"message": message})
+}
@@ -0,0 +1,124 @@
+// Package oidc signs users in with an OpenID Connect provider, using the
+// authorization code flow with PKCE, and keeps them signed in with a session cookie.
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+// minSecretBytes is the shortest session secret accepted, the size of the AES-256 key
+const minSecretBytes = 32
+
+// Options for signing in, set by the flags in cmd/root.go
+var (
+	// Issuer is the URL of the provider, where it's discovered
+	Issuer string
+	// ClientID and ClientSecret are the app's credentials with the provider
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is where the provider sends users back to, the app's /auth/callback
+	RedirectURL = "http://localhost:8080/auth/callback"
+	// Scopes are requested along with openid
+	Scopes = []string{"profile", "email"}
+	// SessionSecret encrypts the session cookies
+	SessionSecret string
+	// SessionTTL is how long users stay signed in
+	SessionTTL = 8 * time.Hour
+	// HTTPTimeout is how long requests to the provider can take
+	HTTPTimeout = 10 * time.Second
+)
+
+// client is how the app talks to the provider, discovered when it's first needed
+type client struct {
+	config   oauth2.Config
+	verifier *gooidc.IDTokenVerifier
+}
+
+var (
+	mu         sync.Mutex
+	discovered *client
+	httpClient *http.Client
+	key        []byte
+	secure     bool
+)
+
+// Init checks the options, the provider is only discovered once someone signs in,
+// so the app starts while it's unreachable
+func Init() error {
+	switch {
+	case Issuer == "":
+		return errors.New("oidc needs the issuer URL of the provider")
+	case ClientID == "":
+		return errors.New("oidc needs a client ID")
+	case len(SessionSecret) < minSecretBytes:
+		return fmt.Errorf("the session secret needs to be at least %d bytes", minSecretBytes)
+	}
+
+	redirect, err := url.Parse(RedirectURL)
+	if err != nil || !redirect.IsAbs() {
+		return fmt.Errorf("the oidc redirect URL %q needs to be absolute", RedirectURL)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	discovered = nil
+	httpClient = &http.Client{Timeout: HTTPTimeout}
+	key = sessionKey(SessionSecret)
+	secure = redirect.Scheme == "https"
+	return nil
+}
+
+// discover returns the client for the provider, fetching its configuration the
+// first time, and again after failing to, so an outage doesn't last past it
+func discover(ctx context.Context) (*client, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if discovered != nil {
+		return discovered, nil
+	}
+	if httpClient == nil {
+		return nil, errors.New("oidc has not been initialized")
+	}
+
+	// The provider keeps the context to fetch its keys later, so it can't be the request's
+	provider, err := gooidc.NewProvider(gooidc.ClientContext(context.WithoutCancel(ctx), httpClient), Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("unable to discover the oidc provider: %w", err)
+	}
+
+	discovered = &client{
+		config: oauth2.Config{
+			ClientID:     ClientID,
+			ClientSecret: ClientSecret,
+			RedirectURL:  RedirectURL,
+			Endpoint:     provider.Endpoint(),
+			Scopes:       append([]string{gooidc.ScopeOpenID}, Scopes...),
+		},
+		verifier: provider.Verifier(&gooidc.Config{ClientID: ClientID}),
+	}
+	return discovered, nil
+}
+
+type sessionKeyType struct{}
+
+// WithSession returns a copy of ctx with s, for FromContext
+func WithSession(ctx context.Context, s *Session) context.Context {
+	return context.WithValue(ctx, sessionKeyType{}, s)
+}
+
+// FromContext returns the session of the signed in user making the request
+func FromContext(ctx context.Context) (*Session, bool) {
+	s, ok := ctx.Value(sessionKeyType{}).(*Session)
+	return s, ok
+}
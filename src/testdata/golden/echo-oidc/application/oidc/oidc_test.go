@@ -0,0 +1,262 @@
+package oidc
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"example.com/portal/oidc/oidctest"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "a-session-secret-that-is-long-enough"
+
+// useOptions sets the options for the test, initializing oidc with them
+func useOptions(t *testing.T, issuer, clientID, redirectURL, sessionSecret string) error {
+	t.Helper()
+
+	prevIssuer, prevID, prevSecret, prevRedirect, prevSession := Issuer, ClientID, ClientSecret, RedirectURL, SessionSecret
+	t.Cleanup(func() {
+		Issuer, ClientID, ClientSecret, RedirectURL, SessionSecret = prevIssuer, prevID, prevSecret, prevRedirect, prevSession
+
+		mu.Lock()
+		discovered, httpClient, key, secure = nil, nil, nil, false
+		mu.Unlock()
+	})
+
+	Issuer, ClientID, ClientSecret, RedirectURL, SessionSecret = issuer, clientID, "client-secret", redirectURL, sessionSecret
+	return Init()
+}
+
+// newApp serves the handlers, with every other path responding with itself,
+// signed in with a mock provider, and returns a client with a cookie jar
+func newApp(t *testing.T) (*httptest.Server, *oidctest.Provider, *http.Client) {
+	t.Helper()
+
+	idp, provider, err := oidctest.NewServer("client-id", "client-secret")
+	require.NoError(t, err)
+	t.Cleanup(idp.Close)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(LoginPath, Login)
+	mux.HandleFunc(CallbackPath, Callback)
+	mux.HandleFunc(LogoutPath, Logout)
+	mux.HandleFunc("/auth/user", Required(User))
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.URL.RequestURI()))
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	require.NoError(t, useOptions(t, idp.URL, "client-id", srv.URL+CallbackPath, testSecret))
+
+	jar, err := cookiejar.New(nil)
+	require.NoError(t, err)
+
+	return srv, provider, &http.Client{Jar: jar}
+}
+
+// noRedirects makes c return redirects rather than follow them
+func noRedirects(c *http.Client) *http.Client {
+	c.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return c
+}
+
+func Test_Init(t *testing.T) {
+	tests := []struct {
+		name        string
+		issuer      string
+		clientID    string
+		redirectURL string
+		secret      string
+		wantErr     string
+	}{
+		{name: "valid", issuer: "http://idp", clientID: "app", redirectURL: "http://app/auth/callback", secret: testSecret},
+		{name: "no issuer", clientID: "app", redirectURL: "http://app/auth/callback", secret: testSecret, wantErr: "issuer"},
+		{name: "no client", issuer: "http://idp", redirectURL: "http://app/auth/callback", secret: testSecret, wantErr: "client ID"},
+		{name: "short secret", issuer: "http://idp", clientID: "app", redirectURL: "http://app/auth/callback", secret: "short", wantErr: "at least 32 bytes"},
+		{name: "relative redirect", issuer: "http://idp", clientID: "app", redirectURL: "/auth/callback", secret: testSecret, wantErr: "absolute"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := useOptions(t, tt.issuer, tt.clientID, tt.redirectURL, tt.secret)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_login(t *testing.T) {
+	srv, provider, client := newApp(t)
+
+	res, err := client.Get(srv.URL + LoginPath + "?return_to=" + url.QueryEscape("/widgets?page=2"))
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.Equal(t, "/widgets?page=2", res.Request.URL.RequestURI())
+
+	res, err = client.Get(srv.URL + "/auth/user")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var s Session
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&s))
+	require.Equal(t, provider.User.Subject, s.Subject)
+	require.Equal(t, provider.User.Email, s.Email)
+	require.WithinDuration(t, time.Now().Add(SessionTTL), s.Expires, time.Minute)
+
+	res, err = client.Get(srv.URL + LogoutPath)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusMethodNotAllowed, res.StatusCode)
+
+	res, err = client.Post(srv.URL+LogoutPath, "", nil)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, "/", res.Request.URL.Path)
+
+	res, err = client.Get(srv.URL + "/auth/user")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusUnauthorized, res.StatusCode)
+}
+
+func Test_Login_unavailable(t *testing.T) {
+	require.NoError(t, useOptions(t, "http://127.0.0.1:1", "client-id", "http://app/auth/callback", testSecret))
+
+	w := httptest.NewRecorder()
+	Login(w, httptest.NewRequest(http.MethodGet, LoginPath, nil))
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func Test_Callback(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(callback url.Values)
+		cookie bool
+		want   int
+	}{
+		{name: "valid", cookie: true, want: http.StatusSeeOther},
+		{name: "no login cookie", want: http.StatusBadRequest},
+		{name: "state mismatch", cookie: true, change: func(q url.Values) { q.Set("state", "forged") }, want: http.StatusBadRequest},
+		{name: "unknown code", cookie: true, change: func(q url.Values) { q.Set("code", "forged") }, want: http.StatusUnauthorized},
+		{name: "provider error", cookie: true, change: func(q url.Values) { q.Del("code"); q.Set("error", "access_denied") }, want: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, _, client := newApp(t)
+			client = noRedirects(client)
+
+			// Sign in with the provider, stopping before going back to the app
+			res, err := client.Get(srv.URL + LoginPath)
+			require.NoError(t, err)
+			res.Body.Close()
+			require.Equal(t, http.StatusFound, res.StatusCode)
+
+			res, err = client.Get(res.Header.Get("Location"))
+			require.NoError(t, err)
+			res.Body.Close()
+			require.Equal(t, http.StatusFound, res.StatusCode)
+
+			callback, err := url.Parse(res.Header.Get("Location"))
+			require.NoError(t, err)
+			q := callback.Query()
+			if tt.change != nil {
+				tt.change(q)
+			}
+			callback.RawQuery = q.Encode()
+
+			if !tt.cookie {
+				jar, err := cookiejar.New(nil)
+				require.NoError(t, err)
+				client.Jar = jar
+			}
+
+			res, err = client.Get(callback.String())
+			require.NoError(t, err)
+			res.Body.Close()
+			require.Equal(t, tt.want, res.StatusCode)
+		})
+	}
+}
+
+func Test_Required(t *testing.T) {
+	require.NoError(t, useOptions(t, "http://idp", "client-id", "http://app/auth/callback", testSecret))
+
+	valid, err := seal(sessionCookie, Session{Subject: "user-1", Expires: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+	expired, err := seal(sessionCookie, Session{Subject: "user-1", Expires: time.Now().Add(-time.Minute)})
+	require.NoError(t, err)
+	login, err := seal(loginCookie, Session{Subject: "user-1", Expires: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		cookie   string
+		accept   string
+		want     int
+		location string
+	}{
+		{name: "signed in", cookie: valid, want: http.StatusOK},
+		{name: "not signed in", want: http.StatusUnauthorized},
+		{name: "expired", cookie: expired, want: http.StatusUnauthorized},
+		{name: "tampered", cookie: valid[:len(valid)-2] + "AA", want: http.StatusUnauthorized},
+		{name: "another cookie", cookie: login, want: http.StatusUnauthorized},
+		{name: "browser", accept: "text/html", want: http.StatusFound, location: LoginPath + "?return_to=%2Fpage%3Fa%3D1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *Session
+			handler := Required(func(w http.ResponseWriter, r *http.Request) {
+				got, _ = FromContext(r.Context())
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/page?a=1", nil)
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: sessionCookie, Value: tt.cookie})
+			}
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+
+			w := httptest.NewRecorder()
+			handler(w, req)
+			require.Equal(t, tt.want, w.Code)
+			require.Equal(t, tt.location, w.Header().Get("Location"))
+			if tt.want == http.StatusOK {
+				require.Equal(t, "user-1", got.Subject)
+			}
+		})
+	}
+}
+
+func Test_localPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "", want: "/"},
+		{path: "/widgets?page=2", want: "/widgets?page=2"},
+		{path: "widgets", want: "/"},
+		{path: "https://evil.example.com/", want: "/"},
+		{path: "//evil.example.com/", want: "/"},
+		{path: "/\\evil.example.com/", want: "/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			require.Equal(t, tt.want, localPath(tt.path))
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package oidc
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Cookie names, the session's for every path and the login's only while signing in
+const (
+	sessionCookie = "session"
+	loginCookie   = "oidc_login"
+)
+
+// loginTTL is how long users have to sign in with the provider
+const loginTTL = 10 * time.Minute
+
+var errNoSession = errors.New("not signed in")
+
+// Session is who's signed in, kept in an encrypted cookie so the app needs no store
+type Session struct {
+	Subject string    `json:"sub"`
+	Email   string    `json:"email,omitempty"`
+	Name    string    `json:"name,omitempty"`
+	Roles   []string  `json:"roles,omitempty"`
+	Expires time.Time `json:"expires"`
+}
+
+// login is what Callback needs to check the provider's response to Login
+type login struct {
+	State    string    `json:"state"`
+	Nonce    string    `json:"nonce"`
+	Verifier string    `json:"verifier"`
+	ReturnTo string    `json:"return_to"`
+	Expires  time.Time `json:"expires"`
+}
+
+// sessionKey derives the AES-256 key cookies are encrypted with from secret
+func sessionKey(secret string) []byte {
+	sum := sha256.Sum256([]byte(secret))
+	return sum[:]
+}
+
+// seal encrypts and authenticates v as the value of the cookie called name,
+// so it can't be read, changed or used as another cookie
+func seal(name string, v any) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	aead, err := newAEAD()
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	rand.Read(nonce)
+	return base64.RawURLEncoding.EncodeToString(aead.Seal(nonce, nonce, b, []byte(name))), nil
+}
+
+// unseal decrypts the value of the cookie called name into v
+func unseal(name, value string, v any) error {
+	b, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return err
+	}
+
+	aead, err := newAEAD()
+	if err != nil {
+		return err
+	}
+	if len(b) < aead.NonceSize() {
+		return errors.New("the cookie is too short")
+	}
+
+	b, err = aead.Open(nil, b[:aead.NonceSize()], b[aead.NonceSize():], []byte(name))
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, v)
+}
+
+func newAEAD() (cipher.AEAD, error) {
+	mu.Lock()
+	k := key
+	mu.Unlock()
+
+	if k == nil {
+		return nil, errors.New("oidc has not been initialized")
+	}
+
+	block, err := aes.NewCipher(k)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// setCookie sets the cookie called name to v, encrypted, for path until expires
+func setCookie(w http.ResponseWriter, name, path string, v any, expires time.Time) error {
+	value, err := seal(name, v)
+	if err != nil {
+		return err
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		Expires:  expires,
+		MaxAge:   int(time.Until(expires).Seconds()),
+		Secure:   isSecure(),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	return nil
+}
+
+// clearCookie removes the cookie called name for path
+func clearCookie(w http.ResponseWriter, name, path string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     name,
+		Path:     path,
+		MaxAge:   -1,
+		Secure:   isSecure(),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func isSecure() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return secure
+}
+
+// FromRequest returns the session of r's cookie while it lasts, for checking requests
+// without Required
+func FromRequest(r *http.Request) (*Session, error) {
+	c, err := r.Cookie(sessionCookie)
+	if err != nil {
+		return nil, errNoSession
+	}
+
+	s := &Session{}
+	if err := unseal(sessionCookie, c.Value, s); err != nil {
+		return nil, errNoSession
+	}
+	if time.Now().After(s.Expires) {
+		return nil, errNoSession
+	}
+
+	return s, nil
+}
@@ -0,0 +1,43 @@
+package actions
+
+import (
+	"example.com/petstore/api"
+	"example.com/petstore/health"
+	"example.com/petstore/middleware"
+	"example.com/petstore/openapi"
+	"github.com/labstack/echo/v4"
+)
+
+// Init initializes the actions package
+func Init() {
+}
+
+// App Creates and returns the application with routes
+func App() *echo.Echo {
+	app := echo.New()
+	app.Use(
+		middleware.RequestID(),
+		middleware.AccessLog(),
+		middleware.Recover(),
+		middleware.CORS(),
+		middleware.BodyLimit(),
+		middleware.Timeout(),
+	)
+
+	app.GET("/", homeView)
+	app.Any("/healthz", echo.WrapHandler(health.Liveness()))
+	app.Any("/readyz", echo.WrapHandler(health.Readiness()))
+	app.Any("/version", echo.WrapHandler(health.Version()))
+	api.Register(app)
+	app.Any("/openapi.json", echo.WrapHandler(openapi.Spec()))
+	app.Any("/docs", echo.WrapHandler(openapi.Docs()))
+	app.Any("/docs/swagger-ui.css", echo.WrapHandler(openapi.Docs()))
+	app.Any("/docs/swagger-ui-bundle.js", echo.WrapHandler(openapi.Docs()))
+	app.Add("GET", "/pets", api.PathValues(api.ListPets(petsService{})))
+	app.Add("POST", "/pets", api.PathValues(api.CreatePet(petsService{})))
+	app.Add("GET", "/pets/:petId", api.PathValues(api.ShowPetByID(petsService{})))
+	app.Add("DELETE", "/pets/:petId", api.PathValues(api.DeletePet(petsService{})))
+	app.Add("POST", "/stores/:storeId/orders", api.PathValues(api.PostStoresStoreIDOrders(storeService{})))
+
+	return app
+}
@@ -0,0 +1,86 @@
+package actions
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"example.com/petstore/api"
+	"example.com/petstore/health"
+)
+
+func (as *ActionSuite) Test_openapi() {
+	req, _ := http.NewRequest(http.MethodGet, "/openapi.json", nil)
+	res := as.serve(req)
+
+	as.Equal(http.StatusOK, res.StatusCode)
+
+	var doc struct {
+		OpenAPI string
+		Paths   map[string]map[string]any
+	}
+	as.NoError(json.NewDecoder(res.Body).Decode(&doc))
+	as.Equal("3.0.3", doc.OpenAPI)
+	as.NotEmpty(doc.Paths)
+
+	// Every documented route has to exist
+	defer func(checks []health.Check) { health.Checks = checks }(health.Checks)
+	health.Checks = nil
+
+	for path, operations := range doc.Paths {
+		if _, ok := operations["get"]; !ok {
+			continue
+		}
+
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		res := as.serve(req)
+		as.NotEqual(http.StatusNotFound, res.StatusCode, path)
+	}
+
+	// And every route of the API has to be documented
+	for _, v := range api.Versions {
+		for _, route := range v.Routes {
+			path := v.Prefix() + route.Path
+			_, ok := doc.Paths[path][strings.ToLower(route.Method)]
+			as.True(ok, "%s %s isn't documented", route.Method, path)
+		}
+	}
+
+	// So does every operation of the API designed up front
+	for _, operation := range [][2]string{
+		{"GET", "/pets"},
+		{"POST", "/pets"},
+		{"GET", "/pets/{petId}"},
+		{"DELETE", "/pets/{petId}"},
+		{"POST", "/stores/{storeId}/orders"},
+	} {
+		_, ok := doc.Paths[operation[1]][strings.ToLower(operation[0])]
+		as.True(ok, "%s %s isn't documented", operation[0], operation[1])
+	}
+}
+
+func (as *ActionSuite) Test_docs() {
+	req, _ := http.NewRequest(http.MethodGet, "/docs", nil)
+	res := as.serve(req)
+
+	as.Equal(http.StatusOK, res.StatusCode)
+
+	body, err := io.ReadAll(res.Body)
+	as.NoError(err)
+	as.Contains(string(body), "/openapi.json")
+
+	// Without loading anything from elsewhere
+	as.NotContains(string(body), "https://")
+
+	for path, contentType := range map[string]string{
+		"/docs/swagger-ui.css":       "text/css",
+		"/docs/swagger-ui-bundle.js": "text/javascript",
+	} {
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		res := as.serve(req)
+
+		as.Equal(http.StatusOK, res.StatusCode, path)
+		as.Contains(res.Header.Get("Content-Type"), contentType, path)
+	}
+}
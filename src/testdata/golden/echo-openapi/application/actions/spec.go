@@ -0,0 +1,42 @@
+package actions
+
+import (
+	"context"
+
+	"example.com/petstore/api"
+)
+
+// petsService implements api.PetsService, replace the stubs with the real thing
+type petsService struct{}
+
+var _ api.PetsService = petsService{}
+
+// ListPets handles GET /pets: List all pets
+func (petsService) ListPets(ctx context.Context, params api.ListPetsParams) (result api.Pets, err error) {
+	return result, api.ErrNotImplemented
+}
+
+// CreatePet handles POST /pets: Create a pet
+func (petsService) CreatePet(ctx context.Context, body api.NewPet) (result api.Pet, err error) {
+	return result, api.ErrNotImplemented
+}
+
+// ShowPetByID handles GET /pets/{petId}: Info for a specific pet
+func (petsService) ShowPetByID(ctx context.Context, params api.ShowPetByIDParams) (result api.Pet, err error) {
+	return result, api.ErrNotImplemented
+}
+
+// DeletePet handles DELETE /pets/{petId}
+func (petsService) DeletePet(ctx context.Context, params api.DeletePetParams) error {
+	return api.ErrNotImplemented
+}
+
+// storeService implements api.StoreService, replace the stubs with the real thing
+type storeService struct{}
+
+var _ api.StoreService = storeService{}
+
+// PostStoresStoreIDOrders handles POST /stores/{storeId}/orders
+func (storeService) PostStoresStoreIDOrders(ctx context.Context, params api.PostStoresStoreIDOrdersParams, body api.PostStoresStoreIDOrdersRequest) (result api.PostStoresStoreIDOrdersResponse, err error) {
+	return result, api.ErrNotImplemented
+}
@@ -0,0 +1,31 @@
+package actions
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Test_spec sends a request to each operation of the API, expect
+// their responses to change as the stubs are implemented
+func (as *ActionSuite) Test_spec() {
+	testCases := []struct {
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{"GET", "/pets", ``, http.StatusNotImplemented},
+		{"POST", "/pets", `{}`, http.StatusNotImplemented},
+		{"GET", "/pets/1", ``, http.StatusNotImplemented},
+		{"DELETE", "/pets/1", ``, http.StatusNotImplemented},
+		{"POST", "/stores/x/orders?dryRun=true", `{}`, http.StatusNotImplemented},
+	}
+	for _, tC := range testCases {
+		as.Run(tC.method+" "+tC.path, func() {
+			req, _ := http.NewRequest(tC.method, tC.path, strings.NewReader(tC.body))
+			res := as.serve(req)
+
+			as.Equal(tC.status, res.StatusCode)
+		})
+	}
+}
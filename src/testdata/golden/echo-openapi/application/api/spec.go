@@ -0,0 +1,278 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// The API from Petstore, generated by makego
+
+// Pet: A pet in the store.
+type Pet struct {
+	ID int64 `json:"id"`
+	Name string `json:"name"`
+	// Groups similar pets.
+	Tag string `json:"tag,omitempty"`
+}
+
+// NewPet is a schema of the API
+type NewPet struct {
+	Name string `json:"name"`
+	Tag string `json:"tag,omitempty"`
+}
+
+// Pets is a schema of the API
+type Pets []Pet
+
+// Error is a schema of the API
+type Error struct {
+	Code int32 `json:"code"`
+	Message string `json:"message"`
+}
+
+// PostStoresStoreIDOrdersRequest is a schema of the API
+type PostStoresStoreIDOrdersRequest struct {
+	Items []PostStoresStoreIDOrdersRequestItemsItem `json:"items"`
+	PlacedAt time.Time `json:"placedAt,omitempty"`
+}
+
+// PostStoresStoreIDOrdersRequestItemsItem is a schema of the API
+type PostStoresStoreIDOrdersRequestItemsItem struct {
+	PetID int64 `json:"petId,omitempty"`
+	Quantity int `json:"quantity,omitempty"`
+}
+
+// PostStoresStoreIDOrdersResponse is a schema of the API
+type PostStoresStoreIDOrdersResponse struct {
+	Status string `json:"status,omitempty"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// ListPetsParams are the parameters of ListPets
+type ListPetsParams struct {
+	Limit int32
+}
+
+// ShowPetByIDParams are the parameters of ShowPetByID
+type ShowPetByIDParams struct {
+	PetID int64
+}
+
+// DeletePetParams are the parameters of DeletePet
+type DeletePetParams struct {
+	PetID int64
+}
+
+// PostStoresStoreIDOrdersParams are the parameters of PostStoresStoreIDOrders
+type PostStoresStoreIDOrdersParams struct {
+	StoreID string
+	XRequestID string
+	DryRun bool
+}
+
+// PetsService handles the operations tagged pets
+type PetsService interface {
+	// ListPets handles GET /pets: List all pets
+	ListPets(ctx context.Context, params ListPetsParams) (Pets, error)
+	// CreatePet handles POST /pets: Create a pet
+	CreatePet(ctx context.Context, body NewPet) (Pet, error)
+	// ShowPetByID handles GET /pets/{petId}: Info for a specific pet
+	ShowPetByID(ctx context.Context, params ShowPetByIDParams) (Pet, error)
+	// DeletePet handles DELETE /pets/{petId}
+	DeletePet(ctx context.Context, params DeletePetParams) error
+}
+
+// ListPets handles GET /pets with s
+func ListPets(s PetsService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var params ListPetsParams
+		if err := readParam(r, "query", "limit", false, &params.Limit); err != nil {
+			writeError(w, err)
+			return
+		}
+
+		result, err := s.ListPets(r.Context(), params)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, result)
+	}
+}
+
+// CreatePet handles POST /pets with s
+func CreatePet(s PetsService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var body NewPet
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeError(w, &StatusError{Status: http.StatusBadRequest, Message: "invalid body: " + err.Error()})
+			return
+		}
+
+		result, err := s.CreatePet(r.Context(), body)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusCreated, result)
+	}
+}
+
+// ShowPetByID handles GET /pets/{petId} with s
+func ShowPetByID(s PetsService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var params ShowPetByIDParams
+		if err := readParam(r, "path", "petId", true, &params.PetID); err != nil {
+			writeError(w, err)
+			return
+		}
+
+		result, err := s.ShowPetByID(r.Context(), params)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, result)
+	}
+}
+
+// DeletePet handles DELETE /pets/{petId} with s
+func DeletePet(s PetsService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var params DeletePetParams
+		if err := readParam(r, "path", "petId", true, &params.PetID); err != nil {
+			writeError(w, err)
+			return
+		}
+
+		err := s.DeletePet(r.Context(), params)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// StoreService handles the operations tagged store
+type StoreService interface {
+	// PostStoresStoreIDOrders handles POST /stores/{storeId}/orders
+	PostStoresStoreIDOrders(ctx context.Context, params PostStoresStoreIDOrdersParams, body PostStoresStoreIDOrdersRequest) (PostStoresStoreIDOrdersResponse, error)
+}
+
+// PostStoresStoreIDOrders handles POST /stores/{storeId}/orders with s
+func PostStoresStoreIDOrders(s StoreService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var params PostStoresStoreIDOrdersParams
+		if err := readParam(r, "path", "storeId", true, &params.StoreID); err != nil {
+			writeError(w, err)
+			return
+		}
+		if err := readParam(r, "header", "X-Request-Id", false, &params.XRequestID); err != nil {
+			writeError(w, err)
+			return
+		}
+		if err := readParam(r, "query", "dryRun", true, &params.DryRun); err != nil {
+			writeError(w, err)
+			return
+		}
+
+		var body PostStoresStoreIDOrdersRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeError(w, &StatusError{Status: http.StatusBadRequest, Message: "invalid body: " + err.Error()})
+			return
+		}
+
+		result, err := s.PostStoresStoreIDOrders(r.Context(), params, body)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusAccepted, result)
+	}
+}
+
+// StatusError is an error with the status to respond with
+type StatusError struct {
+	Status  int    `json:"-"`
+	Message string `json:"message"`
+}
+
+func (e *StatusError) Error() string {
+	return e.Message
+}
+
+// ErrNotImplemented is what the generated stubs return, until they are implemented
+var ErrNotImplemented = &StatusError{Status: http.StatusNotImplemented, Message: "not implemented"}
+
+// paramType is what parameters can be parsed to
+type paramType interface {
+	string | bool | int | int32 | int64 | float32 | float64
+}
+
+// readParam parses the parameter key, from the path, query or headers, into v
+func readParam[T paramType](r *http.Request, in, key string, required bool, v *T) error {
+	var s string
+	switch in {
+	case "path":
+		s = r.PathValue(key)
+	case "query":
+		s = r.URL.Query().Get(key)
+	case "header":
+		s = r.Header.Get(key)
+	}
+
+	if s == "" {
+		if required {
+			return &StatusError{Status: http.StatusBadRequest, Message: fmt.Sprintf("%s is required", key)}
+		}
+		return nil
+	}
+
+	var err error
+	switch p := any(v).(type) {
+	case *string:
+		*p = s
+	case *bool:
+		*p, err = strconv.ParseBool(s)
+	case *int:
+		*p, err = strconv.Atoi(s)
+	case *int32:
+		var n int64
+		n, err = strconv.ParseInt(s, 10, 32)
+		*p = int32(n)
+	case *int64:
+		*p, err = strconv.ParseInt(s, 10, 64)
+	case *float32:
+		var f float64
+		f, err = strconv.ParseFloat(s, 32)
+		*p = float32(f)
+	case *float64:
+		*p, err = strconv.ParseFloat(s, 64)
+	}
+
+	if err != nil {
+		return &StatusError{Status: http.StatusBadRequest, Message: fmt.Sprintf("%s is invalid: %s", key, err)}
+	}
+	return nil
+}
+
+// writeError responds with the status of err when it's a StatusError, 500 Internal Server Error if not
+func writeError(w http.ResponseWriter, err error) {
+	var se *StatusError
+	if !errors.As(err, &se) {
+		se = &StatusError{Status: http.StatusInternalServerError, Message: http.StatusText(http.StatusInternalServerError)}
+	}
+
+	writeJSON(w, se.Status, se)
+}
@@ -0,0 +1,7 @@
+package main
+
+import "example.com/petstore/cmd"
+
+func main() {
+	cmd.Execute()
+}
@@ -0,0 +1,94 @@
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"path"
+
+	swaggerfiles "github.com/swaggo/files/v2"
+
+	"example.com/petstore/app"
+)
+
+// spec is the OpenAPI document, add routes to openapi.json as they are added to the app
+//
+//go:embed openapi.json
+var spec []byte
+
+//go:embed docs.html
+var docs []byte
+
+// designed is the document the API was generated from, whose paths and
+// components are served along with those of spec, replacing any of the same name
+//
+//go:embed api.json
+var designed []byte
+
+// Spec serves the OpenAPI document, with the version of the app when it's set
+func Spec() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var doc map[string]any
+		if err := json.Unmarshal(spec, &doc); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if err := merge(doc, designed); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if info, ok := doc["info"].(map[string]any); ok && app.Version != "" {
+			info["version"] = app.Version
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(doc)
+	})
+}
+
+// Docs serves Swagger UI for the document at /openapi.json, and the
+// scripts and styles it loads from /docs, which are embedded in the app
+func Docs() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if name := path.Base(r.URL.Path); name != "docs" {
+			http.ServeFileFS(w, r, swaggerfiles.FS, name)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(docs)
+	})
+}
+
+// merge adds the paths and components of the document in data to doc
+func merge(doc map[string]any, data []byte) error {
+	var other map[string]any
+	if err := json.Unmarshal(data, &other); err != nil {
+		return err
+	}
+
+	maps.Copy(section(doc, "paths"), section(other, "paths"))
+
+	components := section(doc, "components")
+	for kind, named := range section(other, "components") {
+		if named, ok := named.(map[string]any); ok {
+			maps.Copy(section(components, kind), named)
+		}
+	}
+
+	return nil
+}
+
+// section returns the object at key in doc, adding it when it's missing
+func section(doc map[string]any, key string) map[string]any {
+	s, ok := doc[key].(map[string]any)
+	if !ok {
+		s = map[string]any{}
+		doc[key] = s
+	}
+
+	return s
+}
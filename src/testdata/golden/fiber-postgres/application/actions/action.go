@@ -0,0 +1,48 @@
+package actions
+
+import (
+	"net/http"
+
+	"example.com/fiber/api"
+	"example.com/fiber/health"
+	"example.com/fiber/metrics"
+	"example.com/fiber/middleware"
+	"example.com/fiber/openapi"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+)
+
+// Init initializes the actions package
+func Init() {
+}
+
+// App Creates and returns the application with routes
+func App() *fiber.App {
+	// fiber reads whole bodies before any handler runs, so it's the one to limit them
+	app := fiber.New(fiber.Config{BodyLimit: int(middleware.MaxBodyBytes)})
+	app.Use(
+		middleware.RequestID(),
+		middleware.AccessLog(),
+		middleware.Recover(),
+		middleware.CORS(),
+		middleware.Timeout(),
+	)
+
+	app.Get("/", homeView)
+	handle(app, "/healthz", health.Liveness())
+	handle(app, "/readyz", health.Readiness())
+	handle(app, "/version", health.Version())
+	api.Register(app)
+	handle(app, "/metrics", metrics.Handler())
+	handle(app, "/openapi.json", openapi.Spec())
+	handle(app, "/docs", openapi.Docs())
+	handle(app, "/docs/swagger-ui.css", openapi.Docs())
+	handle(app, "/docs/swagger-ui-bundle.js", openapi.Docs())
+
+	return app
+}
+
+// handle mounts an http.Handler, fiber isn't built on net/http so it needs adapting
+func handle(app *fiber.App, path string, h http.Handler) {
+	app.All(path, adaptor.HTTPHandler(h))
+}
@@ -0,0 +1,7 @@
+package actions
+
+import "github.com/gofiber/fiber/v2"
+
+func homeView(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{"message": "Welcome!"})
+}
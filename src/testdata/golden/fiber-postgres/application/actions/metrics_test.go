@@ -0,0 +1,17 @@
+package actions
+
+import (
+	"io"
+	"net/http"
+)
+
+func (as *ActionSuite) Test_metrics() {
+	req, _ := http.NewRequest(http.MethodGet, "/metrics", nil)
+	res := as.serve(req)
+
+	as.Equal(http.StatusOK, res.StatusCode)
+
+	body, err := io.ReadAll(res.Body)
+	as.NoError(err)
+	as.Contains(string(body), "go_goroutines")
+}
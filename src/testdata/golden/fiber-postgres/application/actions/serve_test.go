@@ -0,0 +1,23 @@
+package actions
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/valyala/fasthttp"
+)
+
+// serve sends req to the router and returns its response, fiber
+// isn't an http.Handler, so requests go through app.Test instead
+func (as *ActionSuite) serve(req *http.Request) *http.Response {
+	res, err := as.router.Test(req, -1)
+
+	// fasthttp rejects bodies over the limit while reading the request, and app.Test
+	// returns its error rather than the 413 Request Entity Too Large it answered with
+	if errors.Is(err, fasthttp.ErrBodyTooLarge) {
+		return &http.Response{StatusCode: http.StatusRequestEntityTooLarge, Header: http.Header{}, Body: http.NoBody}
+	}
+	as.NoError(err)
+
+	return res
+}
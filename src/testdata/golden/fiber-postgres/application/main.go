@@ -0,0 +1,7 @@
+package main
+
+import "example.com/fiber/cmd"
+
+func main() {
+	cmd.Execute()
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+)
+
+// RequestID keeps the request's ID, or makes one, adding it to the locals and response
+func RequestID() fiber.Handler {
+	return requestid.New(requestid.Config{Header: RequestIDHeader})
+}
+
+// GetRequestID returns the ID RequestID gave the request
+func GetRequestID(c *fiber.Ctx) string {
+	id, _ := c.Locals(requestid.ConfigDefault.ContextKey).(string)
+	return id
+}
+
+// AccessLog logs each request once it's done
+func AccessLog() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		if e, ok := err.(*fiber.Error); ok {
+			status = e.Code
+		}
+
+		slog.Info("request",
+			"method", c.Method(),
+			"path", c.Path(),
+			"status", status,
+			"duration", time.Since(start),
+			"request_id", GetRequestID(c),
+		)
+		return err
+	}
+}
+
+// Recover turns panics into internal server errors
+func Recover() fiber.Handler {
+	return recover.New()
+}
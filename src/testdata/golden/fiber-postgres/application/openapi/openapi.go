@@ -0,0 +1,52 @@
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"path"
+
+	swaggerfiles "github.com/swaggo/files/v2"
+
+	"example.com/fiber/app"
+)
+
+// spec is the OpenAPI document, add routes to openapi.json as they are added to the app
+//
+//go:embed openapi.json
+var spec []byte
+
+//go:embed docs.html
+var docs []byte
+
+// Spec serves the OpenAPI document, with the version of the app when it's set
+func Spec() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var doc map[string]any
+		if err := json.Unmarshal(spec, &doc); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if info, ok := doc["info"].(map[string]any); ok && app.Version != "" {
+			info["version"] = app.Version
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(doc)
+	})
+}
+
+// Docs serves Swagger UI for the document at /openapi.json, and the
+// scripts and styles it loads from /docs, which are embedded in the app
+func Docs() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if name := path.Base(r.URL.Path); name != "docs" {
+			http.ServeFileFS(w, r, swaggerfiles.FS, name)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(docs)
+	})
+}
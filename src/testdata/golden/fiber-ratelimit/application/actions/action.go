@@ -0,0 +1,45 @@
+package actions
+
+import (
+	"net/http"
+
+	"example.com/gateway/api"
+	"example.com/gateway/auth"
+	"example.com/gateway/health"
+	"example.com/gateway/middleware"
+	"example.com/gateway/ratelimit"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+)
+
+// Init initializes the actions package
+func Init() {
+}
+
+// App Creates and returns the application with routes
+func App() *fiber.App {
+	// fiber reads whole bodies before any handler runs, so it's the one to limit them
+	app := fiber.New(fiber.Config{BodyLimit: int(middleware.MaxBodyBytes)})
+	app.Use(
+		middleware.RequestID(),
+		middleware.AccessLog(),
+		middleware.Recover(),
+		middleware.CORS(),
+		adaptor.HTTPMiddleware(ratelimit.Middleware),
+		middleware.Timeout(),
+	)
+
+	app.Get("/", homeView)
+	handle(app, "/healthz", health.Liveness())
+	handle(app, "/readyz", health.Readiness())
+	handle(app, "/version", health.Version())
+	api.Register(app)
+	handle(app, "/me", auth.Required(auth.Me))
+
+	return app
+}
+
+// handle mounts an http.Handler, fiber isn't built on net/http so it needs adapting
+func handle(app *fiber.App, path string, h http.Handler) {
+	app.All(path, adaptor.HTTPHandler(h))
+}
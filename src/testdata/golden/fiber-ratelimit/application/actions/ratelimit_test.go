@@ -0,0 +1,73 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"example.com/gateway/middleware"
+	"example.com/gateway/ratelimit"
+)
+
+func (as *ActionSuite) Test_rateLimit() {
+	defer func(l ratelimit.Limit, s ratelimit.Store) {
+		ratelimit.Default, ratelimit.Backend = l, s
+	}(ratelimit.Default, ratelimit.Backend)
+
+	ratelimit.Default = ratelimit.Limit{Name: "app", Requests: 2, Period: time.Minute, Key: ratelimit.ByIP}
+	ratelimit.Backend = ratelimit.NewMemoryStore()
+
+	// Each request claims to come from somewhere else, which a client
+	// can't use to get a new bucket unless a trusted proxy says so
+	request := func(i int) *http.Request {
+		req, _ := http.NewRequest(http.MethodGet, "/healthz", nil)
+		for _, header := range []string{"X-Forwarded-For", "X-Real-IP", "True-Client-IP"} {
+			req.Header.Set(header, fmt.Sprintf("198.51.100.%d", i))
+		}
+		return req
+	}
+
+	for i, remaining := range []string{"1", "0"} {
+		res := as.serve(request(i))
+		as.Equal(http.StatusOK, res.StatusCode)
+		as.Equal("2", res.Header.Get("RateLimit-Limit"))
+		as.Equal(remaining, res.Header.Get("RateLimit-Remaining"))
+	}
+
+	res := as.serve(request(2))
+	as.Equal(http.StatusTooManyRequests, res.StatusCode)
+	as.Equal("2;w=60", res.Header.Get("RateLimit-Policy"))
+	as.NotEmpty(res.Header.Get("Retry-After"))
+}
+
+func (as *ActionSuite) Test_rateLimit_cors() {
+	defer func(l ratelimit.Limit, s ratelimit.Store, origins []string) {
+		ratelimit.Default, ratelimit.Backend, middleware.AllowedOrigins = l, s, origins
+		as.router = App()
+	}(ratelimit.Default, ratelimit.Backend, middleware.AllowedOrigins)
+
+	ratelimit.Default = ratelimit.Limit{Name: "app", Requests: 1, Period: time.Minute, Key: ratelimit.ByIP}
+	ratelimit.Backend = ratelimit.NewMemoryStore()
+	middleware.AllowedOrigins = []string{"https://example.com"}
+	as.router = App()
+
+	// Preflight requests are answered before they get to the limit
+	for range 2 {
+		req, _ := http.NewRequest(http.MethodOptions, "/healthz", nil)
+		req.Header.Set("Origin", "https://example.com")
+		req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+		res := as.serve(req)
+		as.Equal(http.StatusNoContent, res.StatusCode)
+	}
+
+	// And browsers can read why the limit was hit
+	for _, status := range []int{http.StatusOK, http.StatusTooManyRequests} {
+		req, _ := http.NewRequest(http.MethodGet, "/healthz", nil)
+		req.Header.Set("Origin", "https://example.com")
+		res := as.serve(req)
+		as.Equal(status, res.StatusCode)
+		as.Equal("https://example.com", res.Header.Get("Access-Control-Allow-Origin"))
+		as.Contains(res.Header.Get("Access-Control-Expose-Headers"), "RateLimit-Remaining")
+		as.Contains(res.Header.Get("Access-Control-Expose-Headers"), "Retry-After")
+	}
+}
@@ -0,0 +1,38 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"example.com/gateway/ratelimit"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Version_rateLimit(t *testing.T) {
+	defer func(s ratelimit.Store) {
+		ratelimit.Backend = s
+	}(ratelimit.Backend)
+	ratelimit.Backend = ratelimit.NewMemoryStore()
+
+	v := Version{Name: "test", RateLimit: ratelimit.Limit{Requests: 1, Period: time.Minute}}
+	route := Route{Method: http.MethodGet, Path: "/widgets", Handler: listWidgets}
+	h := v.handler(route)
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/api/test/widgets", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "1;w=60", w.Header().Get("RateLimit-Policy"))
+
+	w = httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/api/test/widgets", nil))
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+
+	// Without a limit of its own only the app wide limit applies
+	v.RateLimit = ratelimit.Limit{}
+	w = httptest.NewRecorder()
+	v.handler(route)(w, httptest.NewRequest(http.MethodGet, "/api/test/widgets", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Empty(t, w.Header().Get("RateLimit-Policy"))
+}
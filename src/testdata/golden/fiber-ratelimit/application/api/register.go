@@ -0,0 +1,39 @@
+package api
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+)
+
+// Register adds the routes of every version of the API to app, in a group for each
+func Register(app *fiber.App) {
+	for _, v := range Versions {
+		group := app.Group(v.Prefix())
+		for _, route := range v.Routes {
+			group.Add(route.Method, fiberPath(route.Path), PathValues(v.handler(route)))
+		}
+	}
+}
+
+var pathParam = regexp.MustCompile(`\{([^/{}]+)\}`)
+
+// fiberPath writes the parameters of path the way fiber does, like /widgets/:id
+func fiberPath(path string) string {
+	return pathParam.ReplaceAllString(path, ":$1")
+}
+
+// PathValues adapts h, passing the path parameters on to be read with r.PathValue
+func PathValues(h http.HandlerFunc) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		params := c.AllParams()
+		return adaptor.HTTPHandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for k, v := range params {
+				r.SetPathValue(k, v)
+			}
+			h(w, r)
+		})(c)
+	}
+}
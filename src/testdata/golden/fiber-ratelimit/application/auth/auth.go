@@ -0,0 +1,108 @@
+// Package auth verifies the JSON Web Tokens requests are authenticated with.
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// minSecretBytes is the shortest secret accepted, the size of an HS256 signature
+const minSecretBytes = 32
+
+// Options for verifying tokens, set by the flags in cmd/root.go
+var (
+	// Secret verifies HS256 tokens, when there's no JWKSFile
+	Secret string
+	// JWKSFile is a JSON Web Key Set with the public keys that verify RS256 tokens
+	JWKSFile string
+	// Issuer has to be the iss of tokens, when set
+	Issuer string
+	// Audience has to include an aud of tokens, when set
+	Audience []string
+	// Leeway is the clock skew allowed when checking exp, nbf and iat
+	Leeway = time.Minute
+)
+
+// How tokens are verified, set by Init
+var (
+	keyFunc jwt.Keyfunc
+	methods []string
+)
+
+// Claims are what a token says about who it was issued to, add any others the app's tokens have
+type Claims struct {
+	jwt.RegisteredClaims
+	Roles []string `json:"roles,omitempty"`
+}
+
+// Init loads the key tokens are verified with, from JWKSFile or Secret
+func Init() error {
+	switch {
+	case JWKSFile != "" && Secret != "":
+		return errors.New("auth needs either a secret or a JWKS file, not both")
+	case JWKSFile != "":
+		keys, err := loadJWKS(JWKSFile)
+		if err != nil {
+			return err
+		}
+
+		keyFunc, methods = keys.keyFunc, []string{jwt.SigningMethodRS256.Name}
+	case Secret != "":
+		if len(Secret) < minSecretBytes {
+			return fmt.Errorf("the auth secret needs to be at least %d bytes", minSecretBytes)
+		}
+
+		secret := []byte(Secret)
+		keyFunc = func(*jwt.Token) (any, error) {
+			return secret, nil
+		}
+		methods = []string{jwt.SigningMethodHS256.Name}
+	default:
+		return errors.New("auth needs a secret or a JWKS file to verify tokens with")
+	}
+
+	return nil
+}
+
+// Verify checks the signature and claims of token, returning its claims when it's valid
+func Verify(token string) (*Claims, error) {
+	if keyFunc == nil {
+		return nil, errors.New("auth has not been initialized")
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(Leeway),
+	}
+	if Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(Issuer))
+	}
+	if len(Audience) > 0 {
+		opts = append(opts, jwt.WithAudience(Audience...))
+	}
+
+	claims := &Claims{}
+	if _, err := jwt.ParseWithClaims(token, claims, keyFunc, opts...); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+type claimsKey struct{}
+
+// WithClaims returns a copy of ctx with claims, for FromContext
+func WithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// FromContext returns the claims of the token the request was made with
+func FromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*Claims)
+	return claims, ok
+}
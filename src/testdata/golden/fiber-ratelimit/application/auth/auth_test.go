@@ -0,0 +1,409 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "a-secret-that-is-long-enough-for-hs256"
+
+// useOptions sets the options for the test, initializing auth with them
+func useOptions(t *testing.T, secret, jwksFile, issuer string, audience ...string) error {
+	t.Helper()
+
+	prevSecret, prevFile, prevIssuer, prevAudience := Secret, JWKSFile, Issuer, Audience
+	prevKeyFunc, prevMethods := keyFunc, methods
+	t.Cleanup(func() {
+		Secret, JWKSFile, Issuer, Audience = prevSecret, prevFile, prevIssuer, prevAudience
+		keyFunc, methods = prevKeyFunc, prevMethods
+	})
+
+	Secret, JWKSFile, Issuer, Audience = secret, jwksFile, issuer, audience
+	return Init()
+}
+
+// claims are valid registered claims, changed by change
+func claims(change func(*jwt.RegisteredClaims)) jwt.RegisteredClaims {
+	c := jwt.RegisteredClaims{
+		Subject:   "user-1",
+		Issuer:    "https://issuer.example.com",
+		Audience:  jwt.ClaimStrings{"widgets"},
+		IssuedAt:  jwt.NewNumericDate(time.Now()),
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+	if change != nil {
+		change(&c)
+	}
+
+	return c
+}
+
+// sign mints a token for claims, with kid in its header when set
+func sign(t *testing.T, method jwt.SigningMethod, key any, kid string, claims jwt.Claims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+
+	s, err := token.SignedString(key)
+	require.NoError(t, err)
+	return s
+}
+
+// writeJWKS writes the public keys of keys to a JSON Web Key Set file, by their kid
+func writeJWKS(t *testing.T, keys map[string]*rsa.PrivateKey) string {
+	t.Helper()
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	for kid, key := range keys {
+		set.Keys = append(set.Keys, jwk{
+			Kty: "RSA",
+			Kid: kid,
+			Use: "sig",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		})
+	}
+
+	b, err := json.Marshal(set)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, b, 0o600))
+	return path
+}
+
+func rsaKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return key
+}
+
+func Test_Init(t *testing.T) {
+	jwksFile := writeJWKS(t, map[string]*rsa.PrivateKey{"key-1": rsaKey(t)})
+	empty := filepath.Join(t.TempDir(), "empty.json")
+	require.NoError(t, os.WriteFile(empty, []byte(`{"keys": [{"kty": "EC", "kid": "ec"}]}`), 0o600))
+
+	testCases := []struct {
+		name     string
+		secret   string
+		jwksFile string
+		wantErr  string
+	}{
+		{
+			name:   "secret",
+			secret: testSecret,
+		},
+		{
+			name:     "jwks file",
+			jwksFile: jwksFile,
+		},
+		{
+			name:    "no key",
+			wantErr: "auth needs a secret or a JWKS file",
+		},
+		{
+			name:     "secret and jwks file",
+			secret:   testSecret,
+			jwksFile: jwksFile,
+			wantErr:  "not both",
+		},
+		{
+			name:    "short secret",
+			secret:  "too short",
+			wantErr: "at least 32 bytes",
+		},
+		{
+			name:     "missing jwks file",
+			jwksFile: filepath.Join(t.TempDir(), "missing.json"),
+			wantErr:  "unable to read the JWKS file",
+		},
+		{
+			name:     "no rsa keys",
+			jwksFile: empty,
+			wantErr:  "no RSA signing keys",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			err := useOptions(t, tC.secret, tC.jwksFile, "")
+			if tC.wantErr != "" {
+				require.ErrorContains(t, err, tC.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_Verify(t *testing.T) {
+	require.NoError(t, useOptions(t, testSecret, "", "https://issuer.example.com", "widgets"))
+	key := rsaKey(t)
+
+	testCases := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{
+			name:  "valid",
+			token: sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", claims(nil)),
+		},
+		{
+			name: "expired within the leeway",
+			token: sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", claims(func(c *jwt.RegisteredClaims) {
+				c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-Leeway / 2))
+			})),
+		},
+		{
+			name: "expired",
+			token: sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", claims(func(c *jwt.RegisteredClaims) {
+				c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+			})),
+			wantErr: jwt.ErrTokenExpired,
+		},
+		{
+			name: "without expiry",
+			token: sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", claims(func(c *jwt.RegisteredClaims) {
+				c.ExpiresAt = nil
+			})),
+			wantErr: jwt.ErrTokenRequiredClaimMissing,
+		},
+		{
+			name: "not yet valid",
+			token: sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", claims(func(c *jwt.RegisteredClaims) {
+				c.NotBefore = jwt.NewNumericDate(time.Now().Add(time.Hour))
+			})),
+			wantErr: jwt.ErrTokenNotValidYet,
+		},
+		{
+			name: "other issuer",
+			token: sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", claims(func(c *jwt.RegisteredClaims) {
+				c.Issuer = "https://other.example.com"
+			})),
+			wantErr: jwt.ErrTokenInvalidIssuer,
+		},
+		{
+			name: "other audience",
+			token: sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", claims(func(c *jwt.RegisteredClaims) {
+				c.Audience = jwt.ClaimStrings{"gadgets"}
+			})),
+			wantErr: jwt.ErrTokenInvalidAudience,
+		},
+		{
+			name:    "other secret",
+			token:   sign(t, jwt.SigningMethodHS256, []byte("another-secret-that-is-long-enough"), "", claims(nil)),
+			wantErr: jwt.ErrTokenSignatureInvalid,
+		},
+		{
+			name:    "other method",
+			token:   sign(t, jwt.SigningMethodRS256, key, "", claims(nil)),
+			wantErr: jwt.ErrTokenSignatureInvalid,
+		},
+		{
+			name:    "unsigned",
+			token:   sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, "", claims(nil)),
+			wantErr: jwt.ErrTokenSignatureInvalid,
+		},
+		{
+			name:    "malformed",
+			token:   "not.a.token",
+			wantErr: jwt.ErrTokenMalformed,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			got, err := Verify(tC.token)
+			if tC.wantErr != nil {
+				require.ErrorIs(t, err, tC.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "user-1", got.Subject)
+		})
+	}
+}
+
+func Test_Verify_jwks(t *testing.T) {
+	first, second, other := rsaKey(t), rsaKey(t), rsaKey(t)
+	jwksFile := writeJWKS(t, map[string]*rsa.PrivateKey{"first": first, "second": second})
+	require.NoError(t, useOptions(t, "", jwksFile, ""))
+
+	testCases := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{
+			name:  "first key",
+			token: sign(t, jwt.SigningMethodRS256, first, "first", claims(nil)),
+		},
+		{
+			name:  "second key",
+			token: sign(t, jwt.SigningMethodRS256, second, "second", claims(nil)),
+		},
+		{
+			name:    "wrong kid",
+			token:   sign(t, jwt.SigningMethodRS256, first, "second", claims(nil)),
+			wantErr: true,
+		},
+		{
+			name:    "unknown kid",
+			token:   sign(t, jwt.SigningMethodRS256, other, "other", claims(nil)),
+			wantErr: true,
+		},
+		{
+			name:    "no kid with several keys",
+			token:   sign(t, jwt.SigningMethodRS256, first, "", claims(nil)),
+			wantErr: true,
+		},
+		{
+			name:    "signed with the public key as a secret",
+			token:   sign(t, jwt.SigningMethodHS256, first.N.Bytes(), "first", claims(nil)),
+			wantErr: true,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			got, err := Verify(tC.token)
+			if tC.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "user-1", got.Subject)
+		})
+	}
+}
+
+func Test_Required(t *testing.T) {
+	require.NoError(t, useOptions(t, testSecret, "", ""))
+	handler := Required(func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := FromContext(r.Context())
+		require.True(t, ok)
+		w.Write([]byte(claims.Subject))
+	})
+
+	testCases := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+		wantBody      string
+		wantChallenge string
+	}{
+		{
+			name:          "valid token",
+			authorization: "Bearer " + sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", claims(nil)),
+			wantStatus:    http.StatusOK,
+			wantBody:      "user-1",
+		},
+		{
+			name:          "lowercase scheme",
+			authorization: "bearer " + sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", claims(nil)),
+			wantStatus:    http.StatusOK,
+			wantBody:      "user-1",
+		},
+		{
+			name:          "no token",
+			wantStatus:    http.StatusUnauthorized,
+			wantBody:      `{"message":"missing bearer token"}`,
+			wantChallenge: "Bearer",
+		},
+		{
+			name:          "basic auth",
+			authorization: "Basic dXNlcjpwYXNz",
+			wantStatus:    http.StatusUnauthorized,
+			wantBody:      `{"message":"missing bearer token"}`,
+			wantChallenge: "Bearer",
+		},
+		{
+			name:          "invalid token",
+			authorization: "Bearer not.a.token",
+			wantStatus:    http.StatusUnauthorized,
+			wantBody:      `{"message":"invalid bearer token"}`,
+			wantChallenge: `Bearer error="invalid_token"`,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tC.authorization != "" {
+				req.Header.Set("Authorization", tC.authorization)
+			}
+			rec := httptest.NewRecorder()
+			handler(rec, req)
+
+			require.Equal(t, tC.wantStatus, rec.Code)
+			require.Equal(t, tC.wantBody, strings.TrimSpace(rec.Body.String()))
+			require.Equal(t, tC.wantChallenge, rec.Header().Get("WWW-Authenticate"))
+		})
+	}
+}
+
+func Test_Optional(t *testing.T) {
+	require.NoError(t, useOptions(t, testSecret, "", ""))
+	handler := Optional(func(w http.ResponseWriter, r *http.Request) {
+		if claims, ok := FromContext(r.Context()); ok {
+			w.Write([]byte(claims.Subject))
+			return
+		}
+		w.Write([]byte("anonymous"))
+	})
+
+	testCases := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+		wantBody      string
+	}{
+		{
+			name:          "valid token",
+			authorization: "Bearer " + sign(t, jwt.SigningMethodHS256, []byte(testSecret), "", claims(nil)),
+			wantStatus:    http.StatusOK,
+			wantBody:      "user-1",
+		},
+		{
+			name:       "no token",
+			wantStatus: http.StatusOK,
+			wantBody:   "anonymous",
+		},
+		{
+			name:          "invalid token",
+			authorization: "Bearer not.a.token",
+			wantStatus:    http.StatusUnauthorized,
+			wantBody:      `{"message":"invalid bearer token"}`,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tC.authorization != "" {
+				req.Header.Set("Authorization", tC.authorization)
+			}
+			rec := httptest.NewRecorder()
+			handler(rec, req)
+
+			require.Equal(t, tC.wantStatus, rec.Code)
+			require.Equal(t, tC.wantBody, strings.TrimSpace(rec.Body.String()))
+		})
+	}
+}
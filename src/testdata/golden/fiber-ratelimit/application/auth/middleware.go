@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// ErrNoToken is returned by FromRequest for requests made without a bearer token
+var ErrNoToken = errors.New("missing bearer token")
+
+// Required lets requests with a valid bearer token through to next, with its claims
+// in the context, and responds 401 Unauthorized to any others. Routes of every router
+// are http.HandlerFuncs, so it protects them all the same way, like
+// {Method: http.MethodPost, Path: "/widgets", Handler: auth.Required(createWidget)}.
+func Required(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, err := FromRequest(r)
+		if err != nil {
+			unauthorized(w, err)
+			return
+		}
+
+		next(w, r.WithContext(WithClaims(r.Context(), claims)))
+	}
+}
+
+// Optional lets requests without a bearer token through to next as they are,
+// and those with one once it's verified, with its claims in the context
+func Optional(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, err := FromRequest(r)
+		switch {
+		case errors.Is(err, ErrNoToken):
+			next(w, r)
+		case err != nil:
+			unauthorized(w, err)
+		default:
+			next(w, r.WithContext(WithClaims(r.Context(), claims)))
+		}
+	}
+}
+
+// Me responds with the claims of the request's token, for clients to check who they are
+func Me(w http.ResponseWriter, r *http.Request) {
+	claims, _ := FromContext(r.Context())
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(claims)
+}
+
+// FromRequest verifies the bearer token in the Authorization header of r, for checking
+// requests without Required or Optional
+func FromRequest(r *http.Request) (*Claims, error) {
+	scheme, token, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return nil, ErrNoToken
+	}
+
+	return Verify(token)
+}
+
+// unauthorized tells the client to authenticate with a valid bearer token,
+// without saying what's wrong with the one it sent
+func unauthorized(w http.ResponseWriter, err error) {
+	message, challenge := ErrNoToken.Error(), "Bearer"
+	if !errors.Is(err, ErrNoToken) {
+		message, challenge = "invalid bearer token", `Bearer error="invalid_token"`
+	}
+
+	w.Header().Set("WWW-Authenticate", challenge)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnauthorized)
+	json.NewEncoder(w).Encode(map[string]string{"message": message})
+}
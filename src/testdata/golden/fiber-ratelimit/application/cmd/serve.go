@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"context"
+	"log"
+	"net"
+
+	"example.com/gateway/actions"
+	"example.com/gateway/app"
+)
+
+// serve runs the app until ctx is done, then gives the requests
+// in flight app.ShutdownTimeout to finish before returning
+func serve(ctx context.Context) error {
+	router := actions.App()
+	router.Server().ReadTimeout = app.ReadTimeout
+	router.Server().WriteTimeout = app.WriteTimeout
+	router.Server().IdleTimeout = app.IdleTimeout
+
+	addr := net.JoinHostPort(app.Host, app.Port)
+	errs := make(chan error, 1)
+	go func() {
+		log.Println("listening on", addr)
+		errs <- router.Listen(addr)
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Println("shutting down")
+	if err := router.ShutdownWithTimeout(app.ShutdownTimeout); err != nil {
+		return err
+	}
+
+	return <-errs
+}
@@ -0,0 +1,7 @@
+package main
+
+import "example.com/gateway/cmd"
+
+func main() {
+	cmd.Execute()
+}
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cors"
+)
+
+// CORS allows cross-origin requests from AllowedOrigins, answering preflight requests itself
+func CORS() fiber.Handler {
+	// fiber allows every origin when none are given
+	if len(AllowedOrigins) == 0 {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	return cors.New(cors.Config{
+		AllowOrigins:  strings.Join(AllowedOrigins, ","),
+		ExposeHeaders: strings.Join(ExposedHeaders, ","),
+	})
+}
+
+// Timeout cancels the request's user context after RequestTimeout
+func Timeout() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx, cancel := context.WithTimeout(c.UserContext(), RequestTimeout)
+		defer cancel()
+
+		c.SetUserContext(ctx)
+		return c.Next()
+	}
+}
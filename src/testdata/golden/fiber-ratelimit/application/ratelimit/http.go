@@ -0,0 +1,103 @@
+package ratelimit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ErrTooManyRequests is the message of the 429 Too Many Requests responses
+var ErrTooManyRequests = errors.New("too many requests, try again later")
+
+// Check takes a token for r from its client's bucket of l. When the store can't be
+// reached the request is allowed, so an outage of Redis doesn't take the app down with it.
+func Check(r *http.Request, l Limit) Result {
+	key := l.Key
+	if key == nil {
+		key = Default.Key
+	}
+
+	res, err := Backend.Take(r.Context(), l.Name+":"+key(r), l)
+	if err != nil {
+		slog.Error("unable to check the rate limit", "limit", l.Name, "error", err)
+		return Result{Allowed: true, Limit: l.Capacity(), Remaining: l.Capacity()}
+	}
+
+	return res
+}
+
+// Allow checks r against l, writing the RateLimit headers, and a 429 Too Many
+// Requests when it's over the limit. It reports whether r can go ahead.
+func Allow(w http.ResponseWriter, r *http.Request, l Limit) bool {
+	if !l.Enabled() {
+		return true
+	}
+
+	res := Check(r, l)
+	for k, v := range Headers(l, res) {
+		w.Header().Set(k, v)
+	}
+	if res.Allowed {
+		return true
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusTooManyRequests)
+	json.NewEncoder(w).Encode(map[string]string{"message": ErrTooManyRequests.Error()})
+	return false
+}
+
+// Headers are the RateLimit headers telling the client about res, with
+// Retry-After when it's over the limit
+func Headers(l Limit, res Result) map[string]string {
+	headers := map[string]string{
+		"RateLimit-Limit":     strconv.Itoa(res.Limit),
+		"RateLimit-Remaining": strconv.Itoa(res.Remaining),
+		"RateLimit-Reset":     strconv.Itoa(seconds(res.Reset)),
+		"RateLimit-Policy":    fmt.Sprintf("%d;w=%d", l.Requests, seconds(l.Period)),
+	}
+	if !res.Allowed {
+		headers["Retry-After"] = strconv.Itoa(max(1, seconds(res.RetryAfter)))
+	}
+
+	return headers
+}
+
+// Middleware limits every request to next with Default, the app wide limit
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if Allow(w, r, Default) {
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// Handler returns middleware limiting requests to next with l
+func Handler(l Limit) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if Allow(w, r, l) {
+				next.ServeHTTP(w, r)
+			}
+		})
+	}
+}
+
+// Wrap limits requests to h with l, for routes and groups with a limit of their own
+func Wrap(l Limit, h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if Allow(w, r, l) {
+			h(w, r)
+		}
+	}
+}
+
+// seconds rounds d up to whole seconds, the headers' unit
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,60 @@
+package ratelimit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+
+	"example.com/gateway/auth"
+)
+
+// KeyFunc tells the clients making requests apart, each getting a bucket of its own
+type KeyFunc func(r *http.Request) string
+
+// keyFunc finds the KeyFunc called name, for KeyBy
+func keyFunc(name string) (KeyFunc, error) {
+	switch name {
+	case "ip":
+		return ByIP, nil
+	case "api-key":
+		return ByAPIKey, nil
+	case "subject":
+		return BySubject, nil
+	default:
+		return nil, fmt.Errorf("unknown rate limit key %q, it's one of ip, api-key or subject", name)
+	}
+}
+
+// ByIP tells clients apart by the address they connect from
+func ByIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	return "ip:" + host
+}
+
+// ByAPIKey tells clients apart by the API key in APIKeyHeader, or by their
+// address when they don't send one. Keys are hashed, so they aren't kept as they are.
+func ByAPIKey(r *http.Request) string {
+	key := r.Header.Get(APIKeyHeader)
+	if key == "" {
+		return ByIP(r)
+	}
+
+	sum := sha256.Sum256([]byte(key))
+	return "key:" + hex.EncodeToString(sum[:])
+}
+
+// BySubject tells clients apart by who they're authenticated as, or by their
+// address when they aren't
+func BySubject(r *http.Request) string {
+	if claims, err := auth.FromRequest(r); err == nil && claims.Subject != "" {
+		return "sub:" + claims.Subject
+	}
+
+	return ByIP(r)
+}
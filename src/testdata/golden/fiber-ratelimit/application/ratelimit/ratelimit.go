@@ -0,0 +1,129 @@
+// Package ratelimit limits how often each client can make requests, with token
+// buckets kept in memory, or in Redis to share them between instances of the app.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Options for the app wide limit and the store, set by the flags in cmd/root.go
+var (
+	// Requests is how many requests each client can make every Period, 0 turning the limit off
+	Requests = 100
+	// Period is how long it takes for a client's Requests to be refilled
+	Period = time.Minute
+	// Burst is how many requests a client can make at once, Requests when 0
+	Burst int
+	// KeyBy is what clients are told apart by, their ip, api-key or subject
+	KeyBy = "ip"
+	// APIKeyHeader is where clients send their API key, for ByAPIKey
+	APIKeyHeader = "X-API-Key"
+	// RedisURL is the Redis the buckets are kept in, in memory when it's blank
+	RedisURL string
+)
+
+// Default is the app wide limit, set by Init
+var Default = Limit{Name: "app", Requests: Requests, Period: Period, Key: ByIP}
+
+// Backend is where the buckets are kept, set by Init
+var Backend Store = NewMemoryStore()
+
+// Limit lets each client make Requests every Period, in bursts of up to Burst,
+// with a bucket of tokens that's refilled at that rate and taken from by each request
+type Limit struct {
+	// Name keeps the buckets of limits apart
+	Name     string
+	Requests int
+	Period   time.Duration
+	Burst    int
+	// Key tells clients apart, Default's when nil
+	Key KeyFunc
+}
+
+// Enabled reports whether l limits anything
+func (l Limit) Enabled() bool {
+	return l.Requests > 0 && l.Period > 0
+}
+
+// Capacity is how many tokens l's buckets hold
+func (l Limit) Capacity() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+
+	return l.Requests
+}
+
+// interval is how long it takes for a token to be added to l's buckets
+func (l Limit) interval() time.Duration {
+	return l.Period / time.Duration(l.Requests)
+}
+
+// Result is what taking a token from a bucket left it at
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is how long until the bucket is full again
+	Reset time.Duration
+	// RetryAfter is how long until there's a token to take, when none was
+	RetryAfter time.Duration
+}
+
+// Store keeps the buckets of the clients of each limit
+type Store interface {
+	// Take takes a token from the bucket of l called key, if there's one left
+	Take(ctx context.Context, key string, l Limit) (Result, error)
+}
+
+// Init sets Default from the options, and Backend to Redis when RedisURL is set
+func Init() error {
+	key, err := keyFunc(KeyBy)
+	if err != nil {
+		return err
+	}
+	if Requests < 0 || Burst < 0 || Period <= 0 {
+		return fmt.Errorf("the rate limit of %d requests every %s, in bursts of %d, is invalid", Requests, Period, Burst)
+	}
+
+	Default = Limit{Name: "app", Requests: Requests, Period: Period, Burst: Burst, Key: key}
+
+	if RedisURL != "" {
+		store, err := NewRedisStore(RedisURL)
+		if err != nil {
+			return err
+		}
+
+		Backend = store
+	}
+
+	return nil
+}
+
+// Ping checks Backend can be reached, for the readiness check
+func Ping(ctx context.Context) error {
+	if p, ok := Backend.(interface{ Ping(context.Context) error }); ok {
+		return p.Ping(ctx)
+	}
+
+	return nil
+}
+
+// result works out the Result of a bucket of l with tokens left, once one was taken if allowed
+func result(l Limit, allowed bool, tokens float64) Result {
+	interval := l.interval()
+	res := Result{
+		Allowed:   allowed,
+		Limit:     l.Capacity(),
+		Remaining: int(math.Floor(tokens)),
+		Reset:     time.Duration((float64(l.Capacity()) - tokens) * float64(interval)),
+	}
+	if !allowed {
+		res.RetryAfter = time.Duration((1 - tokens) * float64(interval))
+	}
+
+	return res
+}
@@ -0,0 +1,203 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// useBackend keeps the test's buckets in store, and limits requests to l by default
+func useBackend(t *testing.T, store Store, l Limit) {
+	t.Helper()
+
+	prevStore, prevLimit := Backend, Default
+	t.Cleanup(func() {
+		Backend, Default = prevStore, prevLimit
+	})
+
+	Backend, Default = store, l
+}
+
+// failingStore can't be reached
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, Limit) (Result, error) {
+	return Result{}, errors.New("connection refused")
+}
+
+func Test_MemoryStore_Take(t *testing.T) {
+	now := time.Now()
+	s := NewMemoryStore()
+	s.now = func() time.Time { return now }
+
+	l := Limit{Name: "test", Requests: 2, Period: time.Minute, Burst: 3}
+	tests := []struct {
+		name      string
+		wait      time.Duration
+		allowed   bool
+		remaining int
+	}{
+		{name: "full", allowed: true, remaining: 2},
+		{name: "burst", allowed: true, remaining: 1},
+		{name: "last", allowed: true, remaining: 0},
+		{name: "empty", allowed: false, remaining: 0},
+		{name: "not refilled yet", wait: 29 * time.Second, allowed: false, remaining: 0},
+		{name: "refilled", wait: time.Second, allowed: true, remaining: 0},
+		{name: "refilled to the burst", wait: time.Hour, allowed: true, remaining: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = now.Add(tt.wait)
+
+			res, err := s.Take(context.Background(), "client", l)
+			require.NoError(t, err)
+			require.Equal(t, tt.allowed, res.Allowed)
+			require.Equal(t, tt.remaining, res.Remaining)
+			require.Equal(t, 3, res.Limit)
+			if !tt.allowed {
+				require.Positive(t, res.RetryAfter)
+			}
+		})
+	}
+
+	res, err := s.Take(context.Background(), "another client", l)
+	require.NoError(t, err)
+	require.Equal(t, 2, res.Remaining)
+
+	now = now.Add(time.Hour)
+	s.Take(context.Background(), "client", l)
+	require.Len(t, s.buckets, 1)
+}
+
+func Test_RedisStore_Take(t *testing.T) {
+	mr := miniredis.RunT(t)
+	// Without retrying, so it gives up as soon as Redis is closed
+	s, err := NewRedisStore("redis://" + mr.Addr() + "?max_retries=-1")
+	require.NoError(t, err)
+	defer s.Close()
+
+	ctx := context.Background()
+	require.NoError(t, s.Ping(ctx))
+
+	l := Limit{Name: "test", Requests: 2, Period: time.Minute}
+	for _, remaining := range []int{1, 0} {
+		res, err := s.Take(ctx, "client", l)
+		require.NoError(t, err)
+		require.True(t, res.Allowed)
+		require.Equal(t, remaining, res.Remaining)
+	}
+
+	res, err := s.Take(ctx, "client", l)
+	require.NoError(t, err)
+	require.False(t, res.Allowed)
+	require.Positive(t, res.RetryAfter)
+	require.Positive(t, mr.TTL(keyPrefix+"client"))
+
+	res, err = s.Take(ctx, "another client", l)
+	require.NoError(t, err)
+	require.True(t, res.Allowed)
+
+	mr.Close()
+	_, err = s.Take(ctx, "client", l)
+	require.Error(t, err)
+
+	_, err = NewRedisStore("not a url")
+	require.Error(t, err)
+}
+
+func Test_Init(t *testing.T) {
+	defer func(requests, burst int, keyBy, url string) {
+		Requests, Burst, KeyBy, RedisURL = requests, burst, keyBy, url
+	}(Requests, Burst, KeyBy, RedisURL)
+	useBackend(t, Backend, Default)
+
+	KeyBy = "cookie"
+	require.ErrorContains(t, Init(), "unknown rate limit key")
+
+	KeyBy, Requests = "api-key", -1
+	require.ErrorContains(t, Init(), "invalid")
+
+	Requests, Burst = 10, 20
+	require.NoError(t, Init())
+	require.Equal(t, 20, Default.Capacity())
+	require.IsType(t, &MemoryStore{}, Backend)
+	require.NoError(t, Ping(context.Background()))
+
+	mr := miniredis.RunT(t)
+	RedisURL = "redis://" + mr.Addr()
+	require.NoError(t, Init())
+	require.IsType(t, &RedisStore{}, Backend)
+	require.NoError(t, Ping(context.Background()))
+}
+
+func Test_keys(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = "192.0.2.1:1234"
+
+	require.Equal(t, "ip:192.0.2.1", ByIP(r))
+	require.Equal(t, "ip:192.0.2.1", ByAPIKey(r))
+	require.Equal(t, "ip:192.0.2.1", BySubject(r))
+
+	r.Header.Set(APIKeyHeader, "a-key")
+	key := ByAPIKey(r)
+	require.Regexp(t, "^key:[0-9a-f]{64}$", key)
+	require.NotContains(t, key, "a-key")
+}
+
+func Test_Allow(t *testing.T) {
+	useBackend(t, NewMemoryStore(), Limit{Name: "test", Requests: 1, Period: time.Minute, Key: ByIP})
+
+	w := httptest.NewRecorder()
+	require.True(t, Allow(w, httptest.NewRequest(http.MethodGet, "/", nil), Default))
+	require.Equal(t, "1", w.Header().Get("RateLimit-Limit"))
+	require.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+	require.Equal(t, "1;w=60", w.Header().Get("RateLimit-Policy"))
+	require.Empty(t, w.Header().Get("Retry-After"))
+
+	w = httptest.NewRecorder()
+	require.False(t, Allow(w, httptest.NewRequest(http.MethodGet, "/", nil), Default))
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	require.Equal(t, "60", w.Header().Get("Retry-After"))
+	require.Equal(t, "60", w.Header().Get("RateLimit-Reset"))
+	require.JSONEq(t, `{"message": "too many requests, try again later"}`, w.Body.String())
+
+	// Another limit has buckets of its own
+	w = httptest.NewRecorder()
+	require.True(t, Allow(w, httptest.NewRequest(http.MethodGet, "/", nil), Limit{Name: "other", Requests: 1, Period: time.Minute}))
+
+	// So does another client
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = "192.0.2.2:1234"
+	require.True(t, Allow(httptest.NewRecorder(), r, Default))
+
+	require.True(t, Allow(httptest.NewRecorder(), r, Limit{}))
+}
+
+func Test_Handler(t *testing.T) {
+	tests := []struct {
+		name  string
+		store Store
+		want  []int
+	}{
+		{name: "limited", store: NewMemoryStore(), want: []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests}},
+		{name: "store unavailable", store: failingStore{}, want: []int{http.StatusOK, http.StatusOK, http.StatusOK}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useBackend(t, tt.store, Limit{Name: "test", Requests: 2, Period: time.Minute, Key: ByIP})
+
+			handler := Handler(Default)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+			for _, want := range tt.want {
+				w := httptest.NewRecorder()
+				handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+				require.Equal(t, want, w.Code)
+			}
+		})
+	}
+}
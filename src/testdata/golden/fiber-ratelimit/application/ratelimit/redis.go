@@ -0,0 +1,84 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// keyPrefix keeps the buckets apart from anything else in Redis
+const keyPrefix = "ratelimit:"
+
+// take refills and takes from a bucket in one step, so instances of the app can't
+// both take its last token, using Redis' clock so theirs don't need to agree.
+// It returns whether a token was taken, and how many are left.
+var take = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local interval = tonumber(ARGV[2])
+local time = redis.call("TIME")
+local now = tonumber(time[1]) * 1000000 + tonumber(time[2])
+
+local bucket = redis.call("HMGET", KEYS[1], "tokens", "updated")
+local tokens = tonumber(bucket[1]) or capacity
+local updated = tonumber(bucket[2]) or now
+tokens = math.min(capacity, tokens + (now - updated) / interval)
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "updated", tostring(now))
+redis.call("PEXPIRE", KEYS[1], math.ceil((capacity - tokens) * interval / 1000) + 1000)
+return {allowed, tostring(tokens)}
+`)
+
+// RedisStore keeps buckets in Redis, so every instance of the app shares them
+type RedisStore struct {
+	client *redis.Client
+}
+
+// NewRedisStore connects to the Redis at url, like redis://localhost:6379/0
+func NewRedisStore(url string) (*RedisStore, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the redis url: %w", err)
+	}
+
+	return &RedisStore{client: redis.NewClient(opts)}, nil
+}
+
+// Take takes a token from the bucket of l called key, if there's one left
+func (s *RedisStore) Take(ctx context.Context, key string, l Limit) (Result, error) {
+	// The interval is in microseconds, the resolution of Redis' clock
+	interval := float64(l.interval().Microseconds())
+	reply, err := take.Run(ctx, s.client, []string{keyPrefix + key}, l.Capacity(), interval).Slice()
+	if err != nil {
+		return Result{}, err
+	}
+	if len(reply) != 2 {
+		return Result{}, fmt.Errorf("unexpected reply from the rate limit script: %v", reply)
+	}
+
+	allowed, _ := reply[0].(int64)
+	left, _ := reply[1].(string)
+	tokens, err := strconv.ParseFloat(left, 64)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return result(l, allowed == 1, tokens), nil
+}
+
+// Ping checks Redis can be reached
+func (s *RedisStore) Ping(ctx context.Context) error {
+	return s.client.Ping(ctx).Err()
+}
+
+// Close closes the connections to Redis
+func (s *RedisStore) Close() error {
+	return s.client.Close()
+}
@@ -0,0 +1,40 @@
+package actions
+
+import (
+	"example.com/secure/api"
+	"example.com/secure/auth"
+	"example.com/secure/health"
+	"example.com/secure/middleware"
+	"example.com/secure/openapi"
+	"github.com/gin-gonic/gin"
+)
+
+// Init initializes the actions package
+func Init() {
+}
+
+// App Creates and returns the application with routes
+func App() *gin.Engine {
+	app := gin.New()
+	app.Use(
+		middleware.RequestID(),
+		middleware.AccessLog(),
+		middleware.Recover(),
+		middleware.CORS(),
+		middleware.BodyLimit(),
+		middleware.Timeout(),
+	)
+
+	app.GET("/", homeView)
+	app.Any("/healthz", gin.WrapH(health.Liveness()))
+	app.Any("/readyz", gin.WrapH(health.Readiness()))
+	app.Any("/version", gin.WrapH(health.Version()))
+	api.Register(app)
+	app.Any("/me", gin.WrapH(auth.Required(auth.Me)))
+	app.Any("/openapi.json", gin.WrapH(openapi.Spec()))
+	app.Any("/docs", gin.WrapH(openapi.Docs()))
+	app.Any("/docs/swagger-ui.css", gin.WrapH(openapi.Docs()))
+	app.Any("/docs/swagger-ui-bundle.js", gin.WrapH(openapi.Docs()))
+
+	return app
+}
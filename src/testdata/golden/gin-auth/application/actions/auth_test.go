@@ -0,0 +1,38 @@
+package actions
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"example.com/secure/auth"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func (as *ActionSuite) Test_me() {
+	defer func(secret string) {
+		auth.Secret = secret
+	}(auth.Secret)
+
+	auth.Secret = "a-secret-that-is-long-enough-for-hs256"
+	as.NoError(auth.Init())
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Subject:   "user-1",
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}).SignedString([]byte(auth.Secret))
+	as.NoError(err)
+
+	req, _ := http.NewRequest(http.MethodGet, "/me", nil)
+	res := as.serve(req)
+	as.Equal(http.StatusUnauthorized, res.StatusCode)
+
+	req, _ = http.NewRequest(http.MethodGet, "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	res = as.serve(req)
+	as.Equal(http.StatusOK, res.StatusCode)
+
+	var claims auth.Claims
+	as.NoError(json.NewDecoder(res.Body).Decode(&claims))
+	as.Equal("user-1", claims.Subject)
+}
@@ -0,0 +1,7 @@
+package main
+
+import "example.com/secure/cmd"
+
+func main() {
+	cmd.Execute()
+}
@@ -0,0 +1,61 @@
+/*
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// lambdaCase is an API Gateway proxy event, and what the response to it should be
+type lambdaCase struct {
+	Request  events.APIGatewayProxyRequest
+	Status   int
+	Contains string
+}
+
+// Test_Lambda sends each event in testdata/lambda through the Lambda handler,
+// the same way API Gateway would, add a file there to test another route
+func (as *ActionSuite) Test_Lambda() {
+	files, err := filepath.Glob(filepath.Join("testdata", "lambda", "*.json"))
+	as.NoError(err)
+	as.NotEmpty(files)
+
+	handler := Lambda()
+	for _, file := range files {
+		as.Run(filepath.Base(file), func() {
+			contents, err := os.ReadFile(file)
+			as.NoError(err)
+
+			var tC lambdaCase
+			as.NoError(json.Unmarshal(contents, &tC))
+
+			res, err := handler(context.Background(), tC.Request)
+			as.NoError(err)
+			as.Equal(tC.Status, res.StatusCode)
+			as.Contains(res.Body, tC.Contains)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+/*
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package api
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// Widget is an example resource, replace it with your own
+type Widget struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+var widgets = []Widget{
+	{ID: 1, Name: "Sprocket"},
+	{ID: 2, Name: "Gear"},
+}
+
+func listWidgets(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, widgets)
+}
+
+func getWidget(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": "id must be a number"})
+		return
+	}
+
+	for _, widget := range widgets {
+		if widget.ID == id {
+			writeJSON(w, http.StatusOK, widget)
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusNotFound, map[string]string{"message": "widget not found"})
+}
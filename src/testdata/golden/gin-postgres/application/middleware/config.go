@@ -0,0 +1,46 @@
+/*
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package middleware
+
+import (
+	"slices"
+	"time"
+)
+
+// RequestIDHeader is where the request ID is read from, and written to the response
+const RequestIDHeader = "X-Request-Id"
+
+// Options for the middleware, set by the flags in cmd/root.go
+var (
+	// AllowedOrigins can make cross-origin requests, * allows any origin
+	AllowedOrigins []string
+	// ExposedHeaders are the headers of responses that cross-origin requests can read
+	ExposedHeaders = []string{RequestIDHeader}
+	// MaxBodyBytes is the largest request body accepted
+	MaxBodyBytes int64 = 1 << 20
+	// RequestTimeout is how long a request's context lasts
+	RequestTimeout = 30 * time.Second
+)
+
+// allowOrigin reports whether requests from origin are allowed
+func allowOrigin(origin string) bool {
+	return origin != "" && (slices.Contains(AllowedOrigins, "*") || slices.Contains(AllowedOrigins, origin))
+}
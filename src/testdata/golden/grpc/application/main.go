@@ -0,0 +1,7 @@
+package main
+
+import "example.com/widgets/cmd"
+
+func main() {
+	cmd.Execute()
+}
@@ -0,0 +1,277 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: widgets/v1/widgets.proto
+
+// The Go package is given when generating, with
+// --go_opt=Mwidgets/v1/widgets.proto=<module>/proto/widgets/v1;widgetsv1
+// so the generated stubs don't depend on the module path, see `make proto`
+
+package widgetsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Widget is an example resource
+type Widget struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Widget) Reset() {
+	*x = Widget{}
+	mi := &file_widgets_v1_widgets_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Widget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Widget) ProtoMessage() {}
+
+func (x *Widget) ProtoReflect() protoreflect.Message {
+	mi := &file_widgets_v1_widgets_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Widget.ProtoReflect.Descriptor instead.
+func (*Widget) Descriptor() ([]byte, []int) {
+	return file_widgets_v1_widgets_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Widget) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Widget) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ListWidgetsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWidgetsRequest) Reset() {
+	*x = ListWidgetsRequest{}
+	mi := &file_widgets_v1_widgets_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWidgetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWidgetsRequest) ProtoMessage() {}
+
+func (x *ListWidgetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_widgets_v1_widgets_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWidgetsRequest.ProtoReflect.Descriptor instead.
+func (*ListWidgetsRequest) Descriptor() ([]byte, []int) {
+	return file_widgets_v1_widgets_proto_rawDescGZIP(), []int{1}
+}
+
+type ListWidgetsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Widgets       []*Widget              `protobuf:"bytes,1,rep,name=widgets,proto3" json:"widgets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWidgetsResponse) Reset() {
+	*x = ListWidgetsResponse{}
+	mi := &file_widgets_v1_widgets_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWidgetsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWidgetsResponse) ProtoMessage() {}
+
+func (x *ListWidgetsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_widgets_v1_widgets_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWidgetsResponse.ProtoReflect.Descriptor instead.
+func (*ListWidgetsResponse) Descriptor() ([]byte, []int) {
+	return file_widgets_v1_widgets_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListWidgetsResponse) GetWidgets() []*Widget {
+	if x != nil {
+		return x.Widgets
+	}
+	return nil
+}
+
+type GetWidgetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWidgetRequest) Reset() {
+	*x = GetWidgetRequest{}
+	mi := &file_widgets_v1_widgets_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWidgetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWidgetRequest) ProtoMessage() {}
+
+func (x *GetWidgetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_widgets_v1_widgets_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWidgetRequest.ProtoReflect.Descriptor instead.
+func (*GetWidgetRequest) Descriptor() ([]byte, []int) {
+	return file_widgets_v1_widgets_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetWidgetRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+var File_widgets_v1_widgets_proto protoreflect.FileDescriptor
+
+const file_widgets_v1_widgets_proto_rawDesc = "" +
+	"\n" +
+	"\x18widgets/v1/widgets.proto\x12\n" +
+	"widgets.v1\",\n" +
+	"\x06Widget\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\x14\n" +
+	"\x12ListWidgetsRequest\"C\n" +
+	"\x13ListWidgetsResponse\x12,\n" +
+	"\awidgets\x18\x01 \x03(\v2\x12.widgets.v1.WidgetR\awidgets\"\"\n" +
+	"\x10GetWidgetRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id2\x9e\x01\n" +
+	"\rWidgetService\x12N\n" +
+	"\vListWidgets\x12\x1e.widgets.v1.ListWidgetsRequest\x1a\x1f.widgets.v1.ListWidgetsResponse\x12=\n" +
+	"\tGetWidget\x12\x1c.widgets.v1.GetWidgetRequest\x1a\x12.widgets.v1.Widgetb\x06proto3"
+
+var (
+	file_widgets_v1_widgets_proto_rawDescOnce sync.Once
+	file_widgets_v1_widgets_proto_rawDescData []byte
+)
+
+func file_widgets_v1_widgets_proto_rawDescGZIP() []byte {
+	file_widgets_v1_widgets_proto_rawDescOnce.Do(func() {
+		file_widgets_v1_widgets_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_widgets_v1_widgets_proto_rawDesc), len(file_widgets_v1_widgets_proto_rawDesc)))
+	})
+	return file_widgets_v1_widgets_proto_rawDescData
+}
+
+var file_widgets_v1_widgets_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_widgets_v1_widgets_proto_goTypes = []any{
+	(*Widget)(nil),              // 0: widgets.v1.Widget
+	(*ListWidgetsRequest)(nil),  // 1: widgets.v1.ListWidgetsRequest
+	(*ListWidgetsResponse)(nil), // 2: widgets.v1.ListWidgetsResponse
+	(*GetWidgetRequest)(nil),    // 3: widgets.v1.GetWidgetRequest
+}
+var file_widgets_v1_widgets_proto_depIdxs = []int32{
+	0, // 0: widgets.v1.ListWidgetsResponse.widgets:type_name -> widgets.v1.Widget
+	1, // 1: widgets.v1.WidgetService.ListWidgets:input_type -> widgets.v1.ListWidgetsRequest
+	3, // 2: widgets.v1.WidgetService.GetWidget:input_type -> widgets.v1.GetWidgetRequest
+	2, // 3: widgets.v1.WidgetService.ListWidgets:output_type -> widgets.v1.ListWidgetsResponse
+	0, // 4: widgets.v1.WidgetService.GetWidget:output_type -> widgets.v1.Widget
+	3, // [3:5] is the sub-list for method output_type
+	1, // [1:3] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_widgets_v1_widgets_proto_init() }
+func file_widgets_v1_widgets_proto_init() {
+	if File_widgets_v1_widgets_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_widgets_v1_widgets_proto_rawDesc), len(file_widgets_v1_widgets_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_widgets_v1_widgets_proto_goTypes,
+		DependencyIndexes: file_widgets_v1_widgets_proto_depIdxs,
+		MessageInfos:      file_widgets_v1_widgets_proto_msgTypes,
+	}.Build()
+	File_widgets_v1_widgets_proto = out.File
+	file_widgets_v1_widgets_proto_goTypes = nil
+	file_widgets_v1_widgets_proto_depIdxs = nil
+}
@@ -0,0 +1,80 @@
+package actions
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"example.com/widgets/api"
+	"example.com/widgets/graph"
+	"example.com/widgets/health"
+	"example.com/widgets/middleware"
+	"example.com/widgets/openapi"
+)
+
+// Init initializes the actions package
+func Init() {
+}
+
+// App Creates and returns the application with routes
+func App() http.Handler {
+	app := http.NewServeMux()
+
+	// Patterns can start with a method and have wildcards, like "GET /users/{id}",
+	// read in the handler with r.PathValue("id"). {$} only matches the path itself
+	app.HandleFunc("GET /{$}", homeView)
+	app.Handle("/healthz", health.Liveness())
+	app.Handle("/readyz", health.Readiness())
+	app.Handle("/version", health.Version())
+	api.Register(app)
+	app.Handle("/graphql", graph.Handler())
+	app.Handle("/playground", graph.Playground("/graphql"))
+	app.Handle("/openapi.json", openapi.Spec())
+	app.Handle("/docs", openapi.Docs())
+	app.Handle("/docs/swagger-ui.css", openapi.Docs())
+	app.Handle("/docs/swagger-ui-bundle.js", openapi.Docs())
+
+	return middleware.Wrap(app,
+		middleware.RequestID,
+		middleware.AccessLog,
+		middleware.Recover,
+		middleware.CORS,
+		middleware.BodyLimit,
+		middleware.Timeout,
+	)
+}
+
+// readJSON decodes the JSON request body into v, rejecting fields v doesn't have and
+// bodies over middleware.MaxBodyBytes. When it can't, it answers with 400 Bad Request,
+// or 413 Request Entity Too Large, and returns false.
+func readJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, middleware.MaxBodyBytes))
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(v); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeJSON(w, http.StatusRequestEntityTooLarge, map[string]string{"message": "request body too large"})
+			return false
+		}
+
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": "invalid JSON body: " + err.Error()})
+		return false
+	}
+
+	return true
+}
+
+// writeJSON writes body as the JSON response with status
+func writeJSON(w http.ResponseWriter, status int, body any) error {
+	contents, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_, err = w.Write(contents)
+	return err
+}
@@ -0,0 +1,92 @@
+package actions
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"example.com/widgets/middleware"
+)
+
+func (as *ActionSuite) Test_patterns() {
+	testCases := []struct {
+		name   string
+		method string
+		path   string
+		want   int
+	}{
+		{
+			name:   "home",
+			method: http.MethodGet,
+			path:   "/",
+			want:   http.StatusOK,
+		},
+		{
+			name:   "wrong method",
+			method: http.MethodPost,
+			path:   "/",
+			want:   http.StatusMethodNotAllowed,
+		},
+		{
+			name:   "only the exact path",
+			method: http.MethodGet,
+			path:   "/missing",
+			want:   http.StatusNotFound,
+		},
+	}
+	for _, tC := range testCases {
+		as.Run(tC.name, func() {
+			req, _ := http.NewRequest(tC.method, tC.path, nil)
+			res := as.serve(req)
+
+			as.Equal(tC.want, res.StatusCode)
+		})
+	}
+}
+
+func (as *ActionSuite) Test_readJSON() {
+	defer func(limit int64) { middleware.MaxBodyBytes = limit }(middleware.MaxBodyBytes)
+	middleware.MaxBodyBytes = 32
+
+	testCases := []struct {
+		name string
+		body string
+		want int
+	}{
+		{
+			name: "valid",
+			body: `{"name": "Sprocket"}`,
+			want: http.StatusOK,
+		},
+		{
+			name: "invalid",
+			body: `{"name": `,
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "unknown field",
+			body: `{"name": "Sprocket", "size": 2}`,
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "too large",
+			body: `{"name": "` + strings.Repeat("a", 32) + `"}`,
+			want: http.StatusRequestEntityTooLarge,
+		},
+	}
+	for _, tC := range testCases {
+		as.Run(tC.name, func() {
+			var widget struct {
+				Name string `json:"name"`
+			}
+			w := httptest.NewRecorder()
+			ok := readJSON(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tC.body)), &widget)
+
+			as.Equal(tC.want == http.StatusOK, ok)
+			as.Equal(tC.want, w.Code)
+			if ok {
+				as.Equal("Sprocket", widget.Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package api
+
+import "net/http"
+
+// Register adds the routes of every version of the API to app
+func Register(app *http.ServeMux) {
+	for _, v := range Versions {
+		for _, route := range v.Routes {
+			app.HandleFunc(route.Method+" "+v.Prefix()+route.Path, v.handler(route))
+		}
+	}
+}
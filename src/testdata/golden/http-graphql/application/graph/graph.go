@@ -0,0 +1,31 @@
+package graph
+
+import (
+	"net/http"
+
+	"example.com/widgets/models"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/99designs/gqlgen/graphql/playground"
+)
+
+// Handler serves the GraphQL API, resolved with models.DB
+func Handler() http.Handler {
+	return server(&Resolver{DB: models.DB})
+}
+
+// server serves the GraphQL API with the resolvers of r
+func server(r *Resolver) *handler.Server {
+	srv := handler.New(NewExecutableSchema(Config{Resolvers: r}))
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	srv.Use(extension.Introspection{})
+
+	return srv
+}
+
+// Playground serves a GraphQL playground, querying endpoint
+func Playground(endpoint string) http.Handler {
+	return playground.Handler("Widgets", endpoint)
+}
@@ -0,0 +1,97 @@
+package graph
+
+import (
+	"strconv"
+	"testing"
+
+	"example.com/widgets/models"
+	"github.com/99designs/gqlgen/client"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+// widget is a widget as the API responds with it, with its ID as a string
+type widget struct {
+	ID   string
+	Name string
+}
+
+// GraphSuite runs queries against the resolvers, in a transaction rolled back after each test
+type GraphSuite struct {
+	tx     *gorm.DB
+	client *client.Client
+
+	suite.Suite
+	*require.Assertions
+}
+
+func (gs *GraphSuite) SetupTest() {
+	gs.Assertions = require.New(gs.T())
+
+	gs.tx = models.DB.Begin()
+	gs.NoError(gs.tx.Error)
+	gs.client = client.New(server(&Resolver{DB: gs.tx}))
+}
+
+func (gs *GraphSuite) TearDownTest() {
+	gs.tx.Rollback()
+}
+
+// Test_GraphSuite runs the test suite
+func Test_GraphSuite(t *testing.T) {
+	models.Init()
+
+	suite.Run(t, &GraphSuite{})
+}
+
+func (gs *GraphSuite) Test_createWidget() {
+	var created struct {
+		CreateWidget widget
+	}
+	gs.client.MustPost(`mutation { createWidget(input: {name: "Sprocket"}) { id name } }`, &created)
+	gs.Equal("Sprocket", created.CreateWidget.Name)
+	gs.NotEmpty(created.CreateWidget.ID)
+
+	var listed struct {
+		Widgets []widget
+	}
+	gs.client.MustPost(`{ widgets { id name } }`, &listed)
+	gs.Equal([]widget{created.CreateWidget}, listed.Widgets)
+}
+
+func (gs *GraphSuite) Test_widget() {
+	gear := models.Widget{Name: "Gear"}
+	gs.NoError(gs.tx.Create(&gear).Error)
+
+	testCases := []struct {
+		name string
+		id   uint
+		want *widget
+	}{
+		{
+			name: "found",
+			id:   gear.ID,
+			want: &widget{ID: strconv.FormatUint(uint64(gear.ID), 10), Name: "Gear"},
+		},
+		{
+			name: "not found",
+			id:   gear.ID + 1,
+		},
+	}
+	for _, tC := range testCases {
+		gs.Run(tC.name, func() {
+			var res struct {
+				Widget *widget
+			}
+			gs.client.MustPost(`query($id: ID!) { widget(id: $id) { id name } }`, &res, client.Var("id", tC.id))
+			gs.Equal(tC.want, res.Widget)
+		})
+	}
+}
+
+func (gs *GraphSuite) Test_invalidQuery() {
+	var res struct{}
+	err := gs.client.Post(`{ widgets { color } }`, &res)
+	gs.ErrorContains(err, "Cannot query field")
+}
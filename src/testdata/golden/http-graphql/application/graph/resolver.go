@@ -0,0 +1,8 @@
+package graph
+
+import "gorm.io/gorm"
+
+// Resolver is the root resolver, with what the resolvers need, like the database
+type Resolver struct {
+	DB *gorm.DB
+}
@@ -0,0 +1,55 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"errors"
+
+	"example.com/widgets/graph/model"
+	"example.com/widgets/models"
+	"gorm.io/gorm"
+)
+
+// CreateWidget is the resolver for the createWidget field.
+func (r *mutationResolver) CreateWidget(ctx context.Context, input model.NewWidget) (*models.Widget, error) {
+	widget := &models.Widget{Name: input.Name}
+	if err := r.DB.WithContext(ctx).Create(widget).Error; err != nil {
+		return nil, err
+	}
+
+	return widget, nil
+}
+
+// Widgets is the resolver for the widgets field.
+func (r *queryResolver) Widgets(ctx context.Context) ([]*models.Widget, error) {
+	var widgets []*models.Widget
+	if err := r.DB.WithContext(ctx).Order("id").Find(&widgets).Error; err != nil {
+		return nil, err
+	}
+
+	return widgets, nil
+}
+
+// Widget is the resolver for the widget field.
+func (r *queryResolver) Widget(ctx context.Context, id uint) (*models.Widget, error) {
+	var widget models.Widget
+	err := r.DB.WithContext(ctx).First(&widget, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &widget, nil
+}
+
+// Mutation returns MutationResolver implementation.
+func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+type mutationResolver struct{ *Resolver }
+type queryResolver struct{ *Resolver }
@@ -0,0 +1,7 @@
+package models
+
+// Widget is an example model, served by the GraphQL API in graph
+type Widget struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
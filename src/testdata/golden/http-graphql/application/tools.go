@@ -0,0 +1,6 @@
+//go:build tools
+
+package main
+
+// Keeps gqlgen in go.mod, to generate the GraphQL code with the same version the app uses
+import _ "github.com/99designs/gqlgen"
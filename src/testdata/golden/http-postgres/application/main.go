@@ -0,0 +1,7 @@
+package main
+
+import "example.com/http/cmd"
+
+func main() {
+	cmd.Execute()
+}
@@ -0,0 +1,12 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Handler serves the metrics from the default registry in the Prometheus format
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
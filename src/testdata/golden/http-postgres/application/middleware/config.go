@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"slices"
+	"time"
+)
+
+// RequestIDHeader is where the request ID is read from, and written to the response
+const RequestIDHeader = "X-Request-Id"
+
+// Options for the middleware, set by the flags in cmd/root.go
+var (
+	// AllowedOrigins can make cross-origin requests, * allows any origin
+	AllowedOrigins []string
+	// ExposedHeaders are the headers of responses that cross-origin requests can read
+	ExposedHeaders = []string{RequestIDHeader}
+	// MaxBodyBytes is the largest request body accepted
+	MaxBodyBytes int64 = 1 << 20
+	// RequestTimeout is how long a request's context lasts
+	RequestTimeout = 30 * time.Second
+)
+
+// allowOrigin reports whether requests from origin are allowed
+func allowOrigin(origin string) bool {
+	return origin != "" && (slices.Contains(AllowedOrigins, "*") || slices.Contains(AllowedOrigins, origin))
+}
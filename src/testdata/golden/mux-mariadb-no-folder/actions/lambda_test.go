@@ -0,0 +1,55 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// lambdaCase is an API Gateway proxy event, and what the response to it should be
+type lambdaCase struct {
+	Request  events.APIGatewayProxyRequest
+	Status   int
+	Contains string
+}
+
+// Test_Lambda sends each event in testdata/lambda through the Lambda handler,
+// the same way API Gateway would, add a file there to test another route
+func (as *ActionSuite) Test_Lambda() {
+	files, err := filepath.Glob(filepath.Join("testdata", "lambda", "*.json"))
+	as.NoError(err)
+	as.NotEmpty(files)
+
+	handler := Lambda()
+	for _, file := range files {
+		as.Run(filepath.Base(file), func() {
+			contents, err := os.ReadFile(file)
+			as.NoError(err)
+
+			var tC lambdaCase
+			as.NoError(json.Unmarshal(contents, &tC))
+
+			res, err := handler(context.Background(), tC.Request)
+			as.NoError(err)
+			as.Equal(tC.Status, res.StatusCode)
+			as.Contains(res.Body, tC.Contains)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// Register adds the routes of every version of the API to app, in a subrouter for each
+func Register(app *mux.Router) {
+	for _, v := range Versions {
+		group := app.PathPrefix(v.Prefix()).Subrouter()
+		for _, route := range v.Routes {
+			group.HandleFunc(route.Path, PathValues(v.handler(route))).Methods(route.Method)
+		}
+	}
+}
+
+// PathValues passes the path variables on, to be read with r.PathValue
+func PathValues(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		for k, v := range mux.Vars(r) {
+			r.SetPathValue(k, v)
+		}
+		h(w, r)
+	}
+}
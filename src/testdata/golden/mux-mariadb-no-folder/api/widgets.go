@@ -0,0 +1,52 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// Widget is an example resource, replace it with your own
+type Widget struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+var widgets = []Widget{
+	{ID: 1, Name: "Sprocket"},
+	{ID: 2, Name: "Gear"},
+}
+
+func listWidgets(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, widgets)
+}
+
+func getWidget(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": "id must be a number"})
+		return
+	}
+
+	for _, widget := range widgets {
+		if widget.ID == id {
+			writeJSON(w, http.StatusOK, widget)
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusNotFound, map[string]string{"message": "widget not found"})
+}
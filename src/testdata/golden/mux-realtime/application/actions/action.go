@@ -0,0 +1,51 @@
+package actions
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"example.com/notifier/api"
+	"example.com/notifier/health"
+	"example.com/notifier/middleware"
+	"example.com/notifier/realtime"
+	"github.com/gorilla/mux"
+)
+
+// Init initializes the actions package
+func Init() {
+}
+
+// App Creates and returns the application with routes
+func App() http.Handler {
+	app := mux.NewRouter()
+
+	app.HandleFunc("/", homeView).Methods(http.MethodGet)
+	app.Handle("/healthz", health.Liveness())
+	app.Handle("/readyz", health.Readiness())
+	app.Handle("/version", health.Version())
+	api.Register(app)
+	app.Handle("/ws", realtime.WebSocket(realtime.Default))
+	app.Handle("/events", realtime.SSE(realtime.Default))
+
+	return middleware.Wrap(app,
+		middleware.RequestID,
+		middleware.AccessLog,
+		middleware.Recover,
+		middleware.CORS,
+		middleware.BodyLimit,
+		middleware.Timeout,
+	)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) error {
+	contents, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_, err = w.Write(contents)
+	return err
+}
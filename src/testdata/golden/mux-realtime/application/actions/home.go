@@ -0,0 +1,7 @@
+package actions
+
+import "net/http"
+
+func homeView(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, "Welcome!")
+}
@@ -0,0 +1,60 @@
+package actions
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"example.com/notifier/middleware"
+	"example.com/notifier/realtime"
+	"github.com/coder/websocket"
+)
+
+func (as *ActionSuite) Test_realtime() {
+	// Connections last longer than the request timeout, and the test has a hub of its own
+	defer func(timeout time.Duration, hub *realtime.Hub) {
+		middleware.RequestTimeout, realtime.Default = timeout, hub
+		as.router = App()
+	}(middleware.RequestTimeout, realtime.Default)
+
+	middleware.RequestTimeout = time.Millisecond
+	realtime.Default = realtime.NewHub()
+	as.router = App()
+
+	srv := httptest.NewServer(as.router)
+	defer srv.Close()
+	url := srv.URL
+
+	// Closing the hub ends the connections, which the server waits for
+	defer realtime.Default.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, _, err := websocket.Dial(ctx, url+"/ws", nil)
+	as.NoError(err)
+	defer conn.CloseNow()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url+"/events", nil)
+	req.Header.Set("Accept", "text/event-stream")
+	res, err := http.DefaultClient.Do(req)
+	as.NoError(err)
+	defer res.Body.Close()
+	as.Equal(http.StatusOK, res.StatusCode)
+
+	as.Eventually(func() bool { return realtime.Default.Subscribers() == 2 }, time.Second, time.Millisecond)
+	time.Sleep(10 * middleware.RequestTimeout)
+	as.NoError(realtime.Default.Publish("widget.created", map[string]string{"name": "Sprocket"}))
+
+	_, b, err := conn.Read(ctx)
+	as.NoError(err)
+	as.JSONEq(`{"event": "widget.created", "data": {"name": "Sprocket"}}`, string(b))
+
+	lines := bufio.NewScanner(res.Body)
+	for lines.Scan() && !strings.HasPrefix(lines.Text(), "data: ") {
+	}
+	as.Equal(`data: {"name":"Sprocket"}`, lines.Text())
+}
@@ -0,0 +1,27 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// Register adds the routes of every version of the API to app, in a subrouter for each
+func Register(app *mux.Router) {
+	for _, v := range Versions {
+		group := app.PathPrefix(v.Prefix()).Subrouter()
+		for _, route := range v.Routes {
+			group.HandleFunc(route.Path, PathValues(v.handler(route))).Methods(route.Method)
+		}
+	}
+}
+
+// PathValues passes the path variables on, to be read with r.PathValue
+func PathValues(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		for k, v := range mux.Vars(r) {
+			r.SetPathValue(k, v)
+		}
+		h(w, r)
+	}
+}
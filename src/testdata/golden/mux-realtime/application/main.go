@@ -0,0 +1,7 @@
+package main
+
+import "example.com/notifier/cmd"
+
+func main() {
+	cmd.Execute()
+}
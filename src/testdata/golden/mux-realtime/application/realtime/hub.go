@@ -0,0 +1,126 @@
+// Package realtime pushes messages to clients, over WebSockets or Server-Sent Events.
+package realtime
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// Keepalive settings for the connections
+var (
+	// PingInterval is how often connections are checked, with a ping on WebSockets and a
+	// comment on event streams, which also keeps proxies from closing idle connections
+	PingInterval = 30 * time.Second
+	// WriteTimeout is how long a client has to accept a message, or answer a ping
+	WriteTimeout = 10 * time.Second
+)
+
+// Buffer is how many messages a subscriber can fall behind by before it's dropped
+var Buffer = 16
+
+// Default is the hub served at /ws and /events
+var Default = NewHub()
+
+// Message is published to every subscriber, as JSON over WebSockets,
+// and as an event named Event with Data over Server-Sent Events
+type Message struct {
+	Event string          `json:"event"`
+	Data  json.RawMessage `json:"data"`
+}
+
+// Hub sends the messages published to it to all of its subscribers
+type Hub struct {
+	mu     sync.Mutex
+	subs   map[*Subscription]struct{}
+	closed bool
+}
+
+// NewHub creates a hub without any subscribers
+func NewHub() *Hub {
+	return &Hub{subs: map[*Subscription]struct{}{}}
+}
+
+// Subscription receives the messages published to a hub, until it or the hub is closed
+type Subscription struct {
+	// C is closed once the subscription ends, whether it was closed,
+	// the hub was, or it fell more than Buffer messages behind
+	C <-chan Message
+
+	c   chan Message
+	hub *Hub
+}
+
+// Subscribe starts receiving the messages published to h
+func (h *Hub) Subscribe() *Subscription {
+	c := make(chan Message, Buffer)
+	sub := &Subscription{C: c, c: c, hub: h}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		close(c)
+		return sub
+	}
+
+	h.subs[sub] = struct{}{}
+	return sub
+}
+
+// Publish sends data as JSON to every subscriber as the event named event
+func (h *Hub) Publish(event string, data any) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subs {
+		select {
+		case sub.c <- Message{Event: event, Data: b}:
+		default:
+			// A slow client shouldn't hold up everyone else
+			h.remove(sub)
+		}
+	}
+
+	return nil
+}
+
+// Subscribers is how many subscriptions h has
+func (h *Hub) Subscribers() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return len(h.subs)
+}
+
+// Close ends every subscription, and any made afterwards, for the app to shut down
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for sub := range h.subs {
+		h.remove(sub)
+	}
+}
+
+// remove ends sub, with h.mu held
+func (h *Hub) remove(sub *Subscription) {
+	if _, ok := h.subs[sub]; ok {
+		delete(h.subs, sub)
+		close(sub.c)
+	}
+}
+
+// Close stops receiving messages
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	s.hub.remove(s)
+}
@@ -0,0 +1,159 @@
+package realtime
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Hub(t *testing.T) {
+	defer func(buffer int) { Buffer = buffer }(Buffer)
+	Buffer = 1
+
+	h := NewHub()
+	fast, slow := h.Subscribe(), h.Subscribe()
+	require.Equal(t, 2, h.Subscribers())
+
+	require.NoError(t, h.Publish("first", 1))
+	require.Equal(t, Message{Event: "first", Data: []byte("1")}, <-fast.C)
+
+	// slow hasn't read the first message, so it can't take the second
+	require.NoError(t, h.Publish("second", 2))
+	require.Equal(t, Message{Event: "second", Data: []byte("2")}, <-fast.C)
+	require.Equal(t, Message{Event: "first", Data: []byte("1")}, <-slow.C)
+	_, ok := <-slow.C
+	require.False(t, ok, "slow subscriber should be dropped")
+	require.Equal(t, 1, h.Subscribers())
+
+	h.Close()
+	_, ok = <-fast.C
+	require.False(t, ok, "subscriptions should end with the hub")
+	_, ok = <-h.Subscribe().C
+	require.False(t, ok, "subscriptions should end once the hub is closed")
+	require.Zero(t, h.Subscribers())
+
+	require.Error(t, h.Publish("invalid", func() {}))
+}
+
+func Test_WebSocket(t *testing.T) {
+	defer func(interval time.Duration) { PingInterval = interval }(PingInterval)
+	PingInterval = 10 * time.Millisecond
+
+	h := NewHub()
+	url := serveHub(t, h)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, _, err := websocket.Dial(ctx, url+"/ws", nil)
+	require.NoError(t, err)
+	defer conn.CloseNow()
+	waitForSubscribers(t, h, 1)
+
+	// Reading answers the server's pings, which keep the connection open
+	time.Sleep(5 * PingInterval)
+	require.NoError(t, h.Publish("widget.created", map[string]string{"name": "Sprocket"}))
+
+	typ, b, err := conn.Read(ctx)
+	require.NoError(t, err)
+	require.Equal(t, websocket.MessageText, typ)
+	require.JSONEq(t, `{"event": "widget.created", "data": {"name": "Sprocket"}}`, string(b))
+
+	// Shutting down closes the connection
+	h.Close()
+	_, _, err = conn.Read(ctx)
+	require.Equal(t, websocket.StatusGoingAway, websocket.CloseStatus(err))
+}
+
+func Test_WebSocket_clientMessage(t *testing.T) {
+	h := NewHub()
+	url := serveHub(t, h)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, _, err := websocket.Dial(ctx, url+"/ws", nil)
+	require.NoError(t, err)
+	defer conn.CloseNow()
+	waitForSubscribers(t, h, 1)
+
+	// Clients only listen
+	require.NoError(t, conn.Write(ctx, websocket.MessageText, []byte("hello")))
+	_, _, err = conn.Read(ctx)
+	require.Equal(t, websocket.StatusPolicyViolation, websocket.CloseStatus(err))
+	waitForSubscribers(t, h, 0)
+}
+
+func Test_SSE(t *testing.T) {
+	defer func(interval time.Duration) { PingInterval = interval }(PingInterval)
+	PingInterval = 10 * time.Millisecond
+
+	h := NewHub()
+	url := serveHub(t, h)
+
+	req, _ := http.NewRequest(http.MethodGet, url+"/events", nil)
+	req.Header.Set("Accept", "text/event-stream")
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+	waitForSubscribers(t, h, 1)
+
+	lines := bufio.NewScanner(res.Body)
+	require.True(t, lines.Scan())
+	require.Equal(t, ": ping", lines.Text())
+
+	require.NoError(t, h.Publish("widget.created", map[string]string{"name": "Sprocket"}))
+	var event []string
+	for len(event) < 2 && lines.Scan() {
+		if lines.Text() != "" && !strings.HasPrefix(lines.Text(), ":") {
+			event = append(event, lines.Text())
+		}
+	}
+	require.Equal(t, []string{"event: widget.created", `data: {"name":"Sprocket"}`}, event)
+
+	// Shutting down ends the stream
+	h.Close()
+	for lines.Scan() {
+	}
+	require.NoError(t, lines.Err())
+}
+
+func Test_SSE_notAcceptable(t *testing.T) {
+	h := NewHub()
+	res, err := http.Get(serveHub(t, h) + "/events")
+	require.NoError(t, err)
+	res.Body.Close()
+
+	require.Equal(t, http.StatusNotAcceptable, res.StatusCode)
+	require.Zero(t, h.Subscribers())
+}
+
+// serveHub serves h's WebSocket at /ws and its events at /events until the test ends, returning the server's URL
+func serveHub(t *testing.T, h *Hub) string {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.Handle("/ws", WebSocket(h))
+	mux.Handle("/events", SSE(h))
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	return srv.URL
+}
+
+// waitForSubscribers waits for the handler to have subscribed to, or unsubscribed from, h
+func waitForSubscribers(t *testing.T, h *Hub, n int) {
+	t.Helper()
+	require.Eventually(t, func() bool { return h.Subscribers() == n }, time.Second, time.Millisecond)
+}
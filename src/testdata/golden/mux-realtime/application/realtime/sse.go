@@ -0,0 +1,59 @@
+package realtime
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// SSE sends the messages published to h to clients as Server-Sent Events,
+// to requests that accept text/event-stream, like those from EventSource
+func SSE(h *Hub) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+			http.Error(w, "expected Accept: text/event-stream", http.StatusNotAcceptable)
+			return
+		}
+
+		keepOpen(w)
+		rc := http.NewResponseController(w)
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		if err := rc.Flush(); err != nil {
+			http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+			return
+		}
+
+		sub := h.Subscribe()
+		defer sub.Close()
+
+		ping := time.NewTicker(PingInterval)
+		defer ping.Stop()
+
+		for {
+			var event string
+			select {
+			case msg, ok := <-sub.C:
+				if !ok {
+					return
+				}
+				event = fmt.Sprintf("event: %s\ndata: %s\n\n", msg.Event, msg.Data)
+			case <-ping.C:
+				event = ": ping\n\n"
+			case <-r.Context().Done():
+				return
+			}
+
+			// As with WebSockets, the client has WriteTimeout to accept each event
+			rc.SetWriteDeadline(time.Now().Add(WriteTimeout))
+			if _, err := fmt.Fprint(w, event); err != nil {
+				return
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,89 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/coder/websocket"
+)
+
+// WebSocket sends the messages published to h to WebSocket clients, as JSON.
+// Clients only listen, the connection is closed if they send anything.
+func WebSocket(h *Hub) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keepOpen(w)
+
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			// Accept has already responded
+			return
+		}
+		defer conn.CloseNow()
+
+		sub := h.Subscribe()
+		defer sub.Close()
+
+		// Reading handles pongs and close frames, ctx is done once the client has gone
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+		go func() {
+			defer cancel()
+			if _, _, err := conn.Read(ctx); err == nil {
+				conn.Close(websocket.StatusPolicyViolation, "clients only listen")
+			}
+		}()
+
+		ping := time.NewTicker(PingInterval)
+		defer ping.Stop()
+
+		for {
+			select {
+			case msg, ok := <-sub.C:
+				if !ok {
+					conn.Close(websocket.StatusGoingAway, "subscription ended")
+					return
+				}
+				if err := writeJSON(ctx, conn, msg); err != nil {
+					return
+				}
+			case <-ping.C:
+				if err := pingConn(ctx, conn); err != nil {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+}
+
+// writeJSON sends msg, giving up after WriteTimeout
+func writeJSON(ctx context.Context, conn *websocket.Conn, msg Message) error {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, WriteTimeout)
+	defer cancel()
+
+	return conn.Write(ctx, websocket.MessageText, b)
+}
+
+// pingConn checks that the client is still there, giving up after WriteTimeout
+func pingConn(ctx context.Context, conn *websocket.Conn) error {
+	ctx, cancel := context.WithTimeout(ctx, WriteTimeout)
+	defer cancel()
+
+	return conn.Ping(ctx)
+}
+
+// keepOpen clears the server's read and write timeouts for w's connection,
+// which would otherwise close it while it's still in use
+func keepOpen(w http.ResponseWriter) {
+	rc := http.NewResponseController(w)
+	rc.SetReadDeadline(time.Time{})
+	rc.SetWriteDeadline(time.Time{})
+}
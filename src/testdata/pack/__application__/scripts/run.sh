@@ -0,0 +1,3 @@
+#!/bin/sh
+# {{ not a template }}
+exec go run . "$@"
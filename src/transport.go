@@ -0,0 +1,66 @@
+package src
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Transport is how the generated app is served: over http, grpc, or both.
+type Transport string
+
+const (
+	transportHTTP Transport = "http"
+	transportGRPC Transport = "grpc"
+	transportBoth Transport = "both"
+)
+
+var transports = []Transport{transportHTTP, transportGRPC, transportBoth}
+
+// HTTP reports whether the app is served over HTTP, for templates as {{ if .Transport.HTTP }}.
+func (t Transport) HTTP() bool {
+	return t != transportGRPC
+}
+
+// GRPC reports whether the app has gRPC services, for templates as {{ if .Transport.GRPC }}.
+func (t Transport) GRPC() bool {
+	return t == transportGRPC || t == transportBoth
+}
+
+// setTransport checks the transport, defaulting to http,
+// and enables the grpc feature for gRPC services.
+func (p *Project) setTransport() error {
+	if p.Transport == "" {
+		p.Transport = transportHTTP
+	}
+
+	if !slices.Contains(transports, p.Transport) {
+		return fmt.Errorf("unknown transport %s, expected http, grpc or both", p.Transport)
+	}
+
+	if p.Transport.GRPC() && !FeatureSet(p.Features).Has("grpc") {
+		p.Features = append(slices.Clip(p.Features), "grpc")
+	}
+
+	return nil
+}
+
+// checkTransport makes sure the selected components can be served. The grpc
+// feature enabled by itself, or by another feature, serves both, while
+// anything adding routes needs HTTP.
+func (p *Project) checkTransport() error {
+	if p.featureNames().Has("grpc") && !p.Transport.GRPC() {
+		p.Transport = transportBoth
+	}
+
+	if p.Transport.HTTP() {
+		return nil
+	}
+
+	for _, c := range p.selected {
+		if _, ok := c.Info().Blocks["Routes"]; ok {
+			return incompatibleError(fmt.Sprintf("%s adds routes, which need the http or both transport", describe(c)))
+		}
+	}
+
+	return nil
+}
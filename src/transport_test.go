@@ -0,0 +1,87 @@
+package src
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_setTransport(t *testing.T) {
+	testCases := []struct {
+		name          string
+		transport     Transport
+		features      []string
+		wantTransport Transport
+		wantFeatures  []string
+		wantErr       string
+	}{
+		{
+			name:          "default",
+			wantTransport: transportHTTP,
+		},
+		{
+			name:          "grpc enables the feature",
+			transport:     transportGRPC,
+			features:      []string{"metrics"},
+			wantTransport: transportGRPC,
+			wantFeatures:  []string{"metrics", "grpc"},
+		},
+		{
+			name:          "feature already enabled",
+			transport:     transportBoth,
+			features:      []string{"GRPC"},
+			wantTransport: transportBoth,
+			wantFeatures:  []string{"GRPC"},
+		},
+		{
+			name:      "unknown",
+			transport: "tcp",
+			wantErr:   "unknown transport tcp",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			p := Project{Transport: tC.transport, Features: tC.features}
+
+			err := p.setTransport()
+			if tC.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tC.wantErr) {
+					t.Errorf("expected `%v` to contain `%s`", err, tC.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if tC.wantTransport != p.Transport {
+				t.Errorf("expected transport: %s got: %s", tC.wantTransport, p.Transport)
+			}
+			if !reflect.DeepEqual(tC.wantFeatures, p.Features) {
+				t.Errorf("expected features: %v got: %v", tC.wantFeatures, p.Features)
+			}
+		})
+	}
+}
+
+func Test_Transport(t *testing.T) {
+	testCases := []struct {
+		transport Transport
+		wantHTTP  bool
+		wantGRPC  bool
+	}{
+		{transport: transportHTTP, wantHTTP: true},
+		{transport: transportGRPC, wantGRPC: true},
+		{transport: transportBoth, wantHTTP: true, wantGRPC: true},
+	}
+	for _, tC := range testCases {
+		t.Run(string(tC.transport), func(t *testing.T) {
+			if got := tC.transport.HTTP(); got != tC.wantHTTP {
+				t.Errorf("expected HTTP: %t got: %t", tC.wantHTTP, got)
+			}
+			if got := tC.transport.GRPC(); got != tC.wantGRPC {
+				t.Errorf("expected GRPC: %t got: %t", tC.wantGRPC, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package src
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// parseVar splits a `key=value` override into its key and typed value.
+// The value is read as YAML so `true`, `3` and `[a, b]` keep their types,
+// anything that isn't valid YAML is kept as a plain string.
+func parseVar(s string) (string, any, error) {
+	k, v, ok := strings.Cut(s, "=")
+	k = strings.ToLower(strings.TrimSpace(k))
+	if !ok || k == "" {
+		return "", nil, fmt.Errorf("invalid variable, expected key=value: %s", s)
+	}
+
+	var value any
+	if err := yaml.Unmarshal([]byte(v), &value); err != nil || value == nil {
+		return k, v, nil
+	}
+
+	return k, value, nil
+}
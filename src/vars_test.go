@@ -0,0 +1,96 @@
+package src
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_parseVar(t *testing.T) {
+	testCases := []struct {
+		name      string
+		set       string
+		wantKey   string
+		wantValue any
+		wantErr   string
+	}{
+		{
+			name:    "empty",
+			set:     "",
+			wantErr: "invalid variable",
+		},
+		{
+			name:    "no key",
+			set:     "=value",
+			wantErr: "invalid variable",
+		},
+		{
+			name:      "string",
+			set:       "team=payments",
+			wantKey:   "team",
+			wantValue: "payments",
+		},
+		{
+			name:      "key case",
+			set:       "CostCenter=payments",
+			wantKey:   "costcenter",
+			wantValue: "payments",
+		},
+		{
+			name:      "empty value",
+			set:       "team=",
+			wantKey:   "team",
+			wantValue: "",
+		},
+		{
+			name:      "bool",
+			set:       "oncall=true",
+			wantKey:   "oncall",
+			wantValue: true,
+		},
+		{
+			name:      "number",
+			set:       "replicas=3",
+			wantKey:   "replicas",
+			wantValue: 3,
+		},
+		{
+			name:      "list",
+			set:       "regions=[us, eu]",
+			wantKey:   "regions",
+			wantValue: []any{"us", "eu"},
+		},
+		{
+			name:      "equals in value",
+			set:       "query=a=b",
+			wantKey:   "query",
+			wantValue: "a=b",
+		},
+		{
+			name:      "invalid yaml",
+			set:       "channel=#team: [oops",
+			wantKey:   "channel",
+			wantValue: "#team: [oops",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			key, value, err := parseVar(tC.set)
+			if tC.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tC.wantErr) {
+					t.Errorf("expected `%v` to contain `%s`", err, tC.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if tC.wantKey != key {
+				t.Errorf("expected key: `%s` got: `%s`", tC.wantKey, key)
+			}
+			if !reflect.DeepEqual(tC.wantValue, value) {
+				t.Errorf("expected value: `%#v` got: `%#v`", tC.wantValue, value)
+			}
+		})
+	}
+}
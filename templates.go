@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/jason-jackson/makego/src"
+	"github.com/spf13/cobra"
+)
+
+var strict bool
+
+// templatesCmd groups the commands for working with templates
+var templatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "Work with the templates used to generate projects.",
+}
+
+// lintCmd renders every combination of components and checks the output
+var lintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Render every combination of components and check the output.",
+	Long: `Lint renders all templates, including packs and templates from the config file,
+in memory for every router, ORM, database, license, docker, sentry and header combination.
+Generated Go files are parsed and checked with gofmt, and YAML files are validated.
+
+Combinations the selected components can't be used with are skipped.
+
+Formatting problems are warnings, as generated projects are formatted after they are made,
+use --strict to fail on them as well.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	PreRunE:      loadConfig,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		combos := src.Combinations()
+
+		log.SetOutput(io.Discard)
+		issues, skipped := src.Lint(project, combos)
+		log.SetOutput(os.Stderr)
+
+		// The same problem usually shows up in many combinations, and on
+		// different lines depending on the license header, so group them
+		var order []string
+		grouped := map[string][]src.LintIssue{}
+		errors, warnings := 0, 0
+		for _, i := range issues {
+			key := fmt.Sprint(i.File, i.Template, i.Message)
+			if _, ok := grouped[key]; !ok {
+				order = append(order, key)
+				if i.Warning {
+					warnings++
+				} else {
+					errors++
+				}
+			}
+			grouped[key] = append(grouped[key], i)
+		}
+
+		out := cmd.OutOrStdout()
+		for _, key := range order {
+			group := grouped[key]
+			if group[0].Warning {
+				fmt.Fprint(out, "warning: ")
+			}
+			fmt.Fprintln(out, group[0])
+
+			for n, i := range group {
+				if n == 3 {
+					fmt.Fprintf(out, "    and %d more combinations\n", len(group)-n)
+					break
+				}
+				fmt.Fprintln(out, "   ", i.Combination)
+			}
+		}
+
+		fmt.Fprintf(out, "checked %d combinations: %d errors, %d warnings\n", len(combos)-skipped, errors, warnings)
+		if skipped > 0 {
+			fmt.Fprintf(out, "skipped %d combinations the selected components can't be used with\n", skipped)
+		}
+		if errors > 0 || (strict && warnings > 0) {
+			return fmt.Errorf("templates did not pass lint")
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	lintCmd.Flags().BoolVar(&strict, "strict", false, "fail on formatting warnings as well")
+	lintCmd.Flags().StringVar((*string)(&project.Transport), "transport", "http", "how the app is served in every combination (http, grpc, both)")
+	lintCmd.Flags().StringSliceVar(&project.Features, "feature", nil, "optional feature to enable in every combination, can be repeated")
+	lintCmd.Flags().StringSliceVar(&project.ComponentDirs, "component-dir", nil, "folder of router, ORM and database definitions, can be repeated")
+	lintCmd.Flags().StringSliceVar(&project.Packs, "pack", nil, "folder of extra files laid out like the built-in templates, can be repeated")
+	lintCmd.Flags().StringArrayVar(&project.Set, "set", nil, "set a template variable (key=value), can be repeated")
+
+	templatesCmd.AddCommand(lintCmd)
+	rootCmd.AddCommand(templatesCmd)
+}
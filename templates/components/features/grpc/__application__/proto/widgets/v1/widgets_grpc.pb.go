@@ -0,0 +1,171 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             (unknown)
+// source: widgets/v1/widgets.proto
+
+// The Go package is given when generating, with
+// --go_opt=Mwidgets/v1/widgets.proto=<module>/proto/widgets/v1;widgetsv1
+// so the generated stubs don't depend on the module path, see `make proto`
+
+package widgetsv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	WidgetService_ListWidgets_FullMethodName = "/widgets.v1.WidgetService/ListWidgets"
+	WidgetService_GetWidget_FullMethodName   = "/widgets.v1.WidgetService/GetWidget"
+)
+
+// WidgetServiceClient is the client API for WidgetService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// WidgetService is an example service, replace it with your own
+type WidgetServiceClient interface {
+	// ListWidgets lists every widget
+	ListWidgets(ctx context.Context, in *ListWidgetsRequest, opts ...grpc.CallOption) (*ListWidgetsResponse, error)
+	// GetWidget gets a widget by its id, NOT_FOUND if there is none
+	GetWidget(ctx context.Context, in *GetWidgetRequest, opts ...grpc.CallOption) (*Widget, error)
+}
+
+type widgetServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWidgetServiceClient(cc grpc.ClientConnInterface) WidgetServiceClient {
+	return &widgetServiceClient{cc}
+}
+
+func (c *widgetServiceClient) ListWidgets(ctx context.Context, in *ListWidgetsRequest, opts ...grpc.CallOption) (*ListWidgetsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWidgetsResponse)
+	err := c.cc.Invoke(ctx, WidgetService_ListWidgets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *widgetServiceClient) GetWidget(ctx context.Context, in *GetWidgetRequest, opts ...grpc.CallOption) (*Widget, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Widget)
+	err := c.cc.Invoke(ctx, WidgetService_GetWidget_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WidgetServiceServer is the server API for WidgetService service.
+// All implementations must embed UnimplementedWidgetServiceServer
+// for forward compatibility.
+//
+// WidgetService is an example service, replace it with your own
+type WidgetServiceServer interface {
+	// ListWidgets lists every widget
+	ListWidgets(context.Context, *ListWidgetsRequest) (*ListWidgetsResponse, error)
+	// GetWidget gets a widget by its id, NOT_FOUND if there is none
+	GetWidget(context.Context, *GetWidgetRequest) (*Widget, error)
+	mustEmbedUnimplementedWidgetServiceServer()
+}
+
+// UnimplementedWidgetServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedWidgetServiceServer struct{}
+
+func (UnimplementedWidgetServiceServer) ListWidgets(context.Context, *ListWidgetsRequest) (*ListWidgetsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListWidgets not implemented")
+}
+func (UnimplementedWidgetServiceServer) GetWidget(context.Context, *GetWidgetRequest) (*Widget, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetWidget not implemented")
+}
+func (UnimplementedWidgetServiceServer) mustEmbedUnimplementedWidgetServiceServer() {}
+func (UnimplementedWidgetServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeWidgetServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WidgetServiceServer will
+// result in compilation errors.
+type UnsafeWidgetServiceServer interface {
+	mustEmbedUnimplementedWidgetServiceServer()
+}
+
+func RegisterWidgetServiceServer(s grpc.ServiceRegistrar, srv WidgetServiceServer) {
+	// If the following call panics, it indicates UnimplementedWidgetServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&WidgetService_ServiceDesc, srv)
+}
+
+func _WidgetService_ListWidgets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWidgetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WidgetServiceServer).ListWidgets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WidgetService_ListWidgets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WidgetServiceServer).ListWidgets(ctx, req.(*ListWidgetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WidgetService_GetWidget_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWidgetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WidgetServiceServer).GetWidget(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WidgetService_GetWidget_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WidgetServiceServer).GetWidget(ctx, req.(*GetWidgetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// WidgetService_ServiceDesc is the grpc.ServiceDesc for WidgetService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WidgetService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "widgets.v1.WidgetService",
+	HandlerType: (*WidgetServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListWidgets",
+			Handler:    _WidgetService_ListWidgets_Handler,
+		},
+		{
+			MethodName: "GetWidget",
+			Handler:    _WidgetService_GetWidget_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "widgets/v1/widgets.proto",
+}
@@ -2,5 +2,5 @@ package templates
 
 import "embed"
 
-//go:embed licenses files/*
+//go:embed all:components licenses files/*
 var FS embed.FS